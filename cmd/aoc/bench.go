@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/bench"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func benchCmd(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	day := fs.Int("day", 0, "only benchmark this day (default all days)")
+	part := fs.Int("part", 0, "only benchmark this part (default both parts)")
+	benchtime := fs.Duration("benchtime", time.Second, "minimum time to run each solver for")
+	baselinePath := fs.String("baseline", "bench.json", "path to the baseline file")
+	save := fs.Bool("save", false, "save the results as the new baseline instead of comparing")
+	threshold := fs.Float64("threshold", 0.1, "relative slowdown to flag as a regression, 0.1 is 10%")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	results := make([]bench.Result, 0)
+	for _, key := range registry.Keys() {
+		if (*day != 0 && key.Day != *day) || (*part != 0 && key.Part != *part) {
+			continue
+		}
+
+		inputs, err := benchInputs(key)
+		if err != nil {
+			return fmt.Errorf("finding inputs for %s: %w", key, err)
+		}
+
+		solver, _ := registry.Lookup(key.Day, key.Part, key.Variant)
+		for _, input := range inputs {
+			result, err := bench.Measure(key, solver, input.name, input.data, *benchtime)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "%s\n", result.ID())
+			results = append(results, result)
+		}
+	}
+
+	if *save {
+		if err := (bench.Baseline{Results: results}).Save(*baselinePath); err != nil {
+			return err
+		}
+		return printBenchResults(bench.Baseline{}.Compare(results), *threshold)
+	}
+
+	baseline, err := bench.LoadBaseline(*baselinePath)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "no baseline at %s, run with -save to create one\n", *baselinePath)
+	} else if err != nil {
+		return err
+	}
+
+	return printBenchResults(baseline.Compare(results), *threshold)
+}
+
+type benchInput struct {
+	name string
+	data []byte
+}
+
+// benchInputs returns the golden inputs that have an answer for the key's
+// part and are checked with the key's variant, followed by the day's
+// account inputs in the input store if the key's variant solves those.
+func benchInputs(key registry.Key) ([]benchInput, error) {
+	cases, err := golden.Load(filepath.Join(dayDir(key.Day), golden.Dir))
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]benchInput, 0)
+	for _, c := range golden.ChecksFor(cases, key) {
+		data, err := os.ReadFile(c.Input)
+		if err != nil {
+			return nil, fmt.Errorf("reading input: %w", err)
+		}
+		inputs = append(inputs, benchInput{name: filepath.Base(c.Input), data: data})
+	}
+
+	solvesAccounts := false
+	for _, v := range golden.AccountVariants(cases) {
+		solvesAccounts = solvesAccounts || v == key.Variant
+	}
+	if !solvesAccounts {
+		return inputs, nil
+	}
+
+	store := inputStore()
+	accountInputs, err := store.InputsOf(key.Day)
+	if err != nil {
+		return nil, err
+	}
+	for _, in := range accountInputs {
+		data, err := fs.ReadFile(store.FS(), in.Path)
+		if err != nil {
+			return nil, fmt.Errorf("reading input: %w", err)
+		}
+		inputs = append(inputs, benchInput{name: in.Path, data: data})
+	}
+	return inputs, nil
+}
+
+func printBenchResults(comparisons []bench.Comparison, threshold float64) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SOLVER\tINPUT\tRUNS\tTIME/OP\tALLOCS/OP\tBYTES/OP\tPEAK HEAP\tDELTA\t")
+
+	regressions := 0
+	for _, c := range comparisons {
+		r := c.Current
+
+		delta := "-"
+		if c.HasBase {
+			delta = fmt.Sprintf("%+.1f%%", c.Delta*100)
+		}
+		if c.IsRegression(threshold) {
+			delta += " REGRESSION"
+			regressions++
+		}
+
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%d\t%s\t%d\t%d\t%d\t%s\t\n",
+			r.Key, r.Input, r.Iterations, time.Duration(r.NsPerOp), r.AllocsPerOp, r.BytesPerOp, r.PeakHeap, delta,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if regressions > 0 {
+		return fmt.Errorf("%d solver(s) slower than the baseline by more than %.0f%%", regressions, threshold*100)
+	}
+	return nil
+}
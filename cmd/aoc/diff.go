@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/difftest"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func diffCmd(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to compare the solvers of")
+	part := fs.Int("part", 1, "part to compare the solvers of, 1 or 2")
+	a := fs.String("a", registry.DefaultVariant, "variant of the first solver")
+	b := fs.String("b", "", "variant of the second solver")
+	seeds := fs.Int("seeds", 100, "number of inputs to generate")
+	seed := fs.Int64("seed", 1, "seed of the first input")
+	size := fs.Int("size", 20, "how large inputs to generate, see aoc gen")
+	timeout := fs.Duration("timeout", 10*time.Second, "give up on a solve after this long, 0 means no timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *b == "" {
+		return errors.New("-b must be the variant to compare with")
+	}
+
+	ctx, cancel := solveContext(0)
+	defer cancel()
+
+	m, err := difftest.Find(ctx, difftest.Config{
+		A:         registry.Key{Day: *day, Part: *part, Variant: *a},
+		B:         registry.Key{Day: *day, Part: *part, Variant: *b},
+		Seeds:     *seeds,
+		FirstSeed: *seed,
+		Size:      *size,
+		Timeout:   *timeout,
+	})
+	if err != nil {
+		return err
+	}
+	if m != nil {
+		fmt.Fprintln(os.Stdout, m)
+		return errors.New("the solvers disagree")
+	}
+
+	fmt.Fprintf(os.Stderr, "the solvers agree on all %d inputs\n", *seeds)
+	return nil
+}
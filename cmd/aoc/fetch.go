@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/aocclient"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/inputs"
+)
+
+func fetchCmd(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to fetch the input and instructions for")
+	account := fs.String("account", "", "account to save the input as in the input store (default $AOC_ACCOUNT)")
+	refresh := fs.Bool("refresh", false, "fetch the instructions again, like after solving part 1")
+	newClient := clientFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := aocclient.CheckDay(*day); err != nil {
+		return err
+	}
+	if *account == "" {
+		*account = os.Getenv("AOC_ACCOUNT")
+	}
+	if *account == "" {
+		return errors.New("no account to save the input as, pass -account or set $AOC_ACCOUNT")
+	}
+	if *account == "." || *account == ".." || strings.ContainsAny(*account, `/\`) {
+		return fmt.Errorf("illegal account %q, want the name of a directory in %s", *account, inputs.Dir)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	if *refresh {
+		if err := client.ClearInstructions(*day); err != nil {
+			return fmt.Errorf("clearing cached instructions: %w", err)
+		}
+	}
+
+	ctx := context.Background()
+	input, err := client.Input(ctx, *day)
+	if err != nil {
+		return fmt.Errorf("fetching input: %w", err)
+	}
+	instructions, err := client.Instructions(ctx, *day)
+	if err != nil {
+		return fmt.Errorf("fetching instructions: %w", err)
+	}
+
+	files := map[string][]byte{
+		filepath.Join(inputs.Dir, filepath.FromSlash(inputs.Path(*account, *day))): input,
+		filepath.Join(dayDir(*day), "instructions.txt"):                            instructions,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		fmt.Fprintln(os.Stdout, path)
+	}
+
+	return nil
+}
+
+// clientFlags adds the flags needed to talk to the Advent of Code website
+// and returns a function for creating the client once they're parsed.
+func clientFlags(fs *flag.FlagSet) func() (*aocclient.Client, error) {
+	baseURL := fs.String("base-url", envOr("AOC_BASE_URL", aocclient.DefaultBaseURL), "base URL of the Advent of Code website, or $AOC_BASE_URL")
+	session := fs.String("session", "", "session cookie value (default $AOC_SESSION)")
+	cacheDir := fs.String("cache", "", "directory to cache downloads in (default aoc in the user cache dir)")
+
+	return func() (*aocclient.Client, error) {
+		if *session == "" {
+			*session = os.Getenv("AOC_SESSION")
+		}
+		if *cacheDir == "" {
+			dir, err := aocclient.DefaultCacheDir()
+			if err != nil {
+				return nil, fmt.Errorf("finding cache dir: %w", err)
+			}
+			*cacheDir = dir
+		}
+		return aocclient.NewClient(*baseURL, *session, *cacheDir), nil
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+)
+
+func genCmd(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to generate an input for")
+	size := fs.Int("size", 100, "how large an input to generate, what it means differs between days")
+	seed := fs.Int64("seed", 0, "seed to generate the input from, 0 means a random one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if _, ok := gen.Lookup(*day); !ok {
+		return errors.New("-day must be a day with a generator")
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	// Inputs are written as they're generated, so they can be larger than
+	// what fits in memory.
+	w := bufio.NewWriter(os.Stdout)
+	if err := gen.Write(w, *day, *seed, *size); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing input: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "generated with -seed %d\n", *seed)
+	return nil
+}
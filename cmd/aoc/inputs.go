@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	embedded "github.com/kristofferostlund/adventofcode-2022/inputs"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/inputs"
+)
+
+// inputFlag is the input to solve, chosen by either a path or an account
+// in the input store.
+type inputFlag struct {
+	path    *string
+	account *string
+}
+
+// inputFlags registers the flags for choosing the input to solve on fs.
+func inputFlags(fs *flag.FlagSet) inputFlag {
+	return inputFlag{
+		path:    fs.String("input", "", "path to the puzzle input or - for stdin (default the first account's input in the input store)"),
+		account: fs.String("account", "", "solve the account's input in the input store instead"),
+	}
+}
+
+func (f inputFlag) isSet() bool {
+	return *f.path != "" || *f.account != ""
+}
+
+// open opens the chosen input for the day.
+func (f inputFlag) open(day int) (io.Reader, func() error, error) {
+	if *f.account == "" {
+		return openInput(*f.path, day)
+	}
+	if *f.path != "" {
+		return nil, nil, errors.New("-input can't be combined with -account")
+	}
+
+	file, err := inputStore().Open(*f.account, day)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, file.Close, nil
+}
+
+func openInput(path string, day int) (io.Reader, func() error, error) {
+	switch path {
+	case "-":
+		return os.Stdin, func() error { return nil }, nil
+	case "":
+		return openAccountInput(day)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+// openAccountInput opens the day's input of the first account in the
+// input store that has one.
+func openAccountInput(day int) (io.Reader, func() error, error) {
+	store := inputStore()
+	accountInputs, err := store.InputsOf(day)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(accountInputs) == 0 {
+		return nil, nil, fmt.Errorf("no input for day %d, pass one with -input", day)
+	}
+
+	f, err := store.Open(accountInputs[0].Account, day)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+// inputStore returns the input store in the repository, or the one
+// embedded in the binary if there's no repository to find it in.
+func inputStore() *inputs.Store {
+	if _, err := os.Stat(inputs.Dir); err != nil && embedded.FS != nil {
+		return inputs.New(embedded.FS)
+	}
+	return inputs.New(os.DirFS(inputs.Dir))
+}
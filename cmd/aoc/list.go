@@ -0,0 +1,24 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func listCmd(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tVARIANT")
+	for _, k := range registry.Keys() {
+		fmt.Fprintf(tw, "%d\t%d\t%s\n", k.Day, k.Part, k.Variant)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: aoc <command> [flags]
+
+Commands:
+  run     solve a puzzle part for a given input
+  list    list the registered solvers
+  bench   benchmark the solvers and compare against a baseline
+  new     create the package for a new day
+  fetch   download a day's input and instructions
+  submit  submit an answer, unless it's known to be wrong
+  serve   serve the solvers over HTTP
+  gen     generate a random input for a day
+  diff    compare two solvers on generated inputs
+  profile show where a solver spends its time and allocations
+
+Run "aoc <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "run":
+		err = runCmd(args)
+	case "list":
+		err = listCmd(args)
+	case "bench":
+		err = benchCmd(args)
+	case "new":
+		err = newCmd(args)
+	case "fetch":
+		err = fetchCmd(args)
+	case "submit":
+		err = submitCmd(args)
+	case "serve":
+		err = serveCmd(args)
+	case "gen":
+		err = genCmd(args)
+	case "diff":
+		err = diffCmd(args)
+	case "profile":
+		err = profileCmd(args)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: %v\n", err)
+		os.Exit(1)
+	}
+}
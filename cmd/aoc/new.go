@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/scaffold"
+)
+
+func newCmd(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to create a puzzle package for")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	written, err := scaffold.Generate(".", *day)
+	if err != nil {
+		return fmt.Errorf("scaffolding day %d: %w", *day, err)
+	}
+
+	for _, path := range written {
+		fmt.Fprintln(os.Stdout, path)
+	}
+	return nil
+}
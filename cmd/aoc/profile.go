@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/profile"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+// profileFlags registers the flags for profiling a solver run on fs. The
+// returned function creates the chosen profile files, and the function it
+// returns in turn closes them once the profiles are written.
+func profileFlags(fs *flag.FlagSet) func() (profile.Outputs, func() error, error) {
+	cpuPath := fs.String("cpuprofile", "", "write a CPU profile of the solver to a file")
+	memPath := fs.String("memprofile", "", "write an allocation profile to a file once the solver is done")
+	tracePath := fs.String("trace", "", "write an execution trace of the solver to a file")
+
+	return func() (profile.Outputs, func() error, error) {
+		var outputs profile.Outputs
+		closers := []func() error{}
+		closeAll := func() error {
+			var firstErr error
+			for _, c := range closers {
+				if err := c(); err != nil && firstErr == nil {
+					firstErr = err
+				}
+			}
+			return firstErr
+		}
+
+		for _, o := range []struct {
+			path string
+			w    *io.Writer
+		}{
+			{*cpuPath, &outputs.CPU},
+			{*memPath, &outputs.Mem},
+			{*tracePath, &outputs.Trace},
+		} {
+			if o.path == "" {
+				continue
+			}
+			f, err := os.Create(o.path)
+			if err != nil {
+				closeAll()
+				return profile.Outputs{}, nil, fmt.Errorf("creating profile: %w", err)
+			}
+			*o.w = f
+			closers = append(closers, f.Close)
+		}
+
+		return outputs, closeAll, nil
+	}
+}
+
+func profileCmd(args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to profile")
+	part := fs.Int("part", 1, "part to profile, 1 or 2")
+	variant := fs.String("variant", registry.DefaultVariant, "solver variant to use, see aoc list")
+	input := inputFlags(fs)
+	top := fs.Int("top", 10, "number of functions to show")
+	benchtime := fs.Duration("benchtime", time.Second, "keep solving for at least this long to get enough CPU samples")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	key := registry.Key{Day: *day, Part: *part, Variant: *variant}
+	solver, err := lookupSolver(key.Day, key.Part, key.Variant)
+	if err != nil {
+		return err
+	}
+
+	reader, closeInput, err := input.open(key.Day)
+	if err != nil {
+		return fmt.Errorf("opening input: %w", err)
+	}
+	data, err := io.ReadAll(reader)
+	closeInput()
+	if err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+
+	ctx, cancel := solveContext(0)
+	defer cancel()
+
+	solve := func() error {
+		if _, err := solver.Solve(ctx, bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("solving %s: %w", key, err)
+		}
+		return nil
+	}
+
+	var cpu bytes.Buffer
+	stop, err := profile.Start(profile.Outputs{CPU: &cpu})
+	if err != nil {
+		return err
+	}
+	runs := 0
+	start := time.Now()
+	for runs == 0 || time.Since(start) < *benchtime {
+		if err := solve(); err != nil {
+			stop()
+			return err
+		}
+		runs++
+	}
+	elapsed := time.Since(start)
+	if err := stop(); err != nil {
+		return err
+	}
+
+	// The allocations are counted over as many runs again, with the CPU
+	// profile stopped so that its writer's allocations aren't counted.
+	// Those made before solving are subtracted using this profile.
+	var allocsBefore, allocs bytes.Buffer
+	if err := profile.WriteAllocs(&allocsBefore); err != nil {
+		return err
+	}
+	for i := 0; i < runs; i++ {
+		if err := solve(); err != nil {
+			return err
+		}
+	}
+	if err := profile.WriteAllocs(&allocs); err != nil {
+		return err
+	}
+
+	cpuSummary, err := profile.Summarize(&cpu, "cpu")
+	if err != nil {
+		return fmt.Errorf("summarizing CPU profile: %w", err)
+	}
+	base, err := profile.Summarize(&allocsBefore, "alloc_space")
+	if err != nil {
+		return fmt.Errorf("summarizing allocation profile: %w", err)
+	}
+	allocSummary, err := profile.Summarize(&allocs, "alloc_space")
+	if err != nil {
+		return fmt.Errorf("summarizing allocation profile: %w", err)
+	}
+	allocSummary = allocSummary.Sub(base)
+
+	fmt.Fprintf(os.Stdout, "solved %s %d time(s) in %s\n\n", key, runs, elapsed)
+	fmt.Fprintln(os.Stdout, "By CPU:")
+	if err := printProfileSummary(cpuSummary, *top); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, "\nBy allocation:")
+	return printProfileSummary(allocSummary, *top)
+}
+
+func printProfileSummary(s profile.Summary, n int) error {
+	percent := func(v int64) string {
+		if s.Total == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", float64(v)/float64(s.Total)*100)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FLAT\tFLAT%\tCUM\tCUM%\tFUNCTION\t")
+	for _, f := range s.Top(n) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", s.Format(f.Flat), percent(f.Flat), s.Format(f.Cum), percent(f.Cum), f.Name)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/profile"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/all"
+)
+
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to solve")
+	part := fs.Int("part", 1, "part to solve, 1 or 2")
+	variant := fs.String("variant", registry.DefaultVariant, "solver variant to use, see aoc list")
+	input := inputFlags(fs)
+	timeout := fs.Duration("timeout", 0, "give up solving after this long, 0 means no timeout")
+	all := fs.Bool("all", false, "solve every registered part on its golden inputs and every account's inputs, and report the results")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of parts to solve at once with -all")
+	format := fs.String("format", "markdown", "report format with -all, markdown or json")
+	explainAnswer := fs.Bool("explain", false, "print how the solver got to its answer, for solvers that can explain it")
+	withTracer := traceFlags(fs)
+	createProfiles := profileFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *all {
+		if *day != 0 || input.isSet() {
+			return errors.New("-all can't be combined with -day, -input or -account")
+		}
+		return runAll(*workers, *timeout, *format)
+	}
+
+	ctx, cancel := solveContext(*timeout)
+	defer cancel()
+
+	ctx, closeTracer, err := withTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	var explanation *explain.Explanation
+	if *explainAnswer {
+		ctx, explanation = explain.With(ctx)
+	}
+
+	profiles, closeProfiles, err := createProfiles()
+	if err != nil {
+		closeTracer()
+		return err
+	}
+
+	key := registry.Key{Day: *day, Part: *part, Variant: *variant}
+	answer, elapsed, err := solve(ctx, key, input, profiles)
+	if closeErr := closeTracer(); closeErr != nil && err == nil {
+		err = fmt.Errorf("closing tracer: %w", closeErr)
+	}
+	if closeErr := closeProfiles(); closeErr != nil && err == nil {
+		err = fmt.Errorf("closing profiles: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, answer)
+	if *explainAnswer {
+		if len(explanation.Entries()) == 0 {
+			fmt.Fprintf(os.Stderr, "%s can't explain its answer\n", key)
+		}
+		fmt.Fprint(os.Stdout, explanation)
+	}
+	fmt.Fprintf(os.Stderr, "took %s\n", elapsed)
+
+	return nil
+}
+
+// solveContext returns a context which is cancelled on Ctrl-C and, if
+// timeout is positive, once the timeout has passed.
+func solveContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// solve solves the key's part for the input, profiling only the solver
+// itself into profiles.
+func solve(ctx context.Context, key registry.Key, input inputFlag, profiles profile.Outputs) (answers.Answer, time.Duration, error) {
+	solver, err := lookupSolver(key.Day, key.Part, key.Variant)
+	if err != nil {
+		return answers.Answer{}, 0, err
+	}
+
+	reader, closeInput, err := input.open(key.Day)
+	if err != nil {
+		return answers.Answer{}, 0, fmt.Errorf("opening input: %w", err)
+	}
+	defer closeInput()
+
+	stopProfiles, err := profile.Start(profiles)
+	if err != nil {
+		return answers.Answer{}, 0, err
+	}
+	start := time.Now()
+	answer, err := solver.Solve(ctx, reader)
+	elapsed := time.Since(start)
+	if stopErr := stopProfiles(); stopErr != nil && err == nil {
+		return answers.Answer{}, elapsed, stopErr
+	}
+	if err != nil {
+		return answers.Answer{}, elapsed, fmt.Errorf("solving %s: %w", key, err)
+	}
+
+	return answer, elapsed, nil
+}
+
+func lookupSolver(day, part int, variant string) (registry.Solver, error) {
+	solver, ok := registry.Lookup(day, part, variant)
+	if !ok {
+		return nil, fmt.Errorf("no solver registered for %s", registry.Key{Day: day, Part: part, Variant: variant})
+	}
+	return solver, nil
+}
+
+// dayDir is the directory of the day's puzzle package relative to the
+// root of the repository, which is where aoc is expected to be run from.
+func dayDir(day int) string {
+	return filepath.Join("puzzles", fmt.Sprintf("day%d", day))
+}
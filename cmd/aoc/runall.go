@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/report"
+)
+
+// runAll solves every registered part on each of its golden inputs, as
+// well as on every account's input for the day, giving each solve at most
+// timeout if it's positive.
+func runAll(workers int, timeout time.Duration, format string) error {
+	var write func(r report.Report) error
+	switch format {
+	case "markdown":
+		write = func(r report.Report) error { return r.WriteMarkdown(os.Stdout) }
+	case "json":
+		write = func(r report.Report) error { return r.WriteJSON(os.Stdout) }
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+
+	tasks, err := allTasks()
+	if err != nil {
+		return err
+	}
+
+	// The timeout is per solve, so the whole run only stops on Ctrl-C.
+	ctx, cancel := solveContext(0)
+	defer cancel()
+
+	start := time.Now()
+	r := report.Run(ctx, tasks, workers, timeout)
+	if err := write(r); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "solved %d inputs in %s\n", len(r.Rows), time.Since(start))
+
+	if failures := r.Failures(); failures > 0 {
+		return fmt.Errorf("%d of %d solves failed or got the wrong answer", failures, len(r.Rows))
+	}
+	return nil
+}
+
+func allTasks() ([]report.Task, error) {
+	casesByDay := make(map[int][]golden.Case)
+	for _, day := range registry.Days() {
+		cases, err := golden.Load(filepath.Join(dayDir(day), golden.Dir))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("loading golden answers for day %d: %w", day, err)
+		}
+		casesByDay[day] = cases
+	}
+
+	tasks := make([]report.Task, 0)
+	for _, key := range registry.Keys() {
+		for _, c := range golden.ChecksFor(casesByDay[key.Day], key) {
+			tasks = append(tasks, report.Task{Key: key, Input: c.Input, Want: c.Want})
+		}
+	}
+
+	// Only the default variants are sure to solve any real input, the
+	// others solve them when the day's golden answers say so.
+	store := inputStore()
+	accountInputs, err := store.Inputs()
+	if err != nil {
+		return nil, fmt.Errorf("loading the input store: %w", err)
+	}
+	for _, in := range accountInputs {
+		variants := make(map[string]bool)
+		for _, v := range golden.AccountVariants(casesByDay[in.Day]) {
+			variants[v] = true
+		}
+		for _, key := range registry.Keys() {
+			if key.Day != in.Day || !variants[key.Variant] {
+				continue
+			}
+			tasks = append(tasks, report.Task{
+				Key:     key,
+				Input:   in.Path,
+				Want:    in.Answers[key.Part],
+				Account: in.Account,
+				FS:      store.FS(),
+			})
+		}
+	}
+
+	return tasks, nil
+}
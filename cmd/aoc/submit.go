@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/aocclient"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/profile"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func submitCmd(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to submit the answer for")
+	part := fs.Int("part", 1, "part to submit the answer for, 1 or 2")
+	answerFlag := fs.String("answer", "", "answer to submit (default solve the part like aoc run)")
+	variant := fs.String("variant", registry.DefaultVariant, "solver variant to use when solving")
+	input := inputFlags(fs)
+	force := fs.Bool("force", false, "submit even if the history says the answer is wrong")
+	timeout := fs.Duration("timeout", 0, "give up solving after this long, 0 means no timeout")
+	newClient := clientFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := aocclient.CheckDay(*day); err != nil {
+		return err
+	}
+	if err := aocclient.CheckPart(*part); err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	answer := answerOf(*answerFlag)
+	if answer.IsZero() {
+		key := registry.Key{Day: *day, Part: *part, Variant: *variant}
+		ctx, cancel := solveContext(*timeout)
+		defer cancel()
+
+		solved, elapsed, err := solve(ctx, key, input, profile.Outputs{})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "solved %s in %s\n", key, elapsed)
+		if solved.Kind() == answers.KindRendered {
+			return fmt.Errorf("not submitting the rendered answer, read it and pass it with -answer:\n%s", solved)
+		}
+		answer = solved
+	}
+
+	history, err := aocclient.LoadHistory(client.HistoryPath())
+	if err != nil {
+		return err
+	}
+	if err := history.Check(*day, *part, answer); err != nil && !*force {
+		return fmt.Errorf("not submitting %s: %w", answer, err)
+	}
+
+	result, err := client.Submit(context.Background(), *day, *part, answer)
+	if err != nil {
+		return fmt.Errorf("submitting: %w", err)
+	}
+	if err := history.Record(*day, *part, answer, result.Verdict); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: %s\n", answer, result.Verdict)
+	if result.Wait > 0 {
+		fmt.Fprintf(os.Stdout, "wait %s before submitting again\n", result.Wait)
+	}
+	if result.Verdict == aocclient.VerdictUnknown {
+		fmt.Fprintln(os.Stdout, result.Message)
+	}
+
+	return nil
+}
+
+func answerOf(s string) answers.Answer {
+	if s == "" {
+		return answers.Answer{}
+	}
+	if i, err := strconv.Atoi(s); err == nil {
+		return answers.Int(i)
+	}
+	return answers.String(s)
+}
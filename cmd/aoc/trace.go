@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/trace"
+)
+
+// traceFlags registers the flags for tracing solvers on fs. The returned
+// function attaches the chosen tracers to ctx, and the function it returns
+// in turn must be called once solving is done.
+func traceFlags(fs *flag.FlagSet) func(ctx context.Context) (context.Context, func() error, error) {
+	progress := fs.Bool("progress", false, "show a live progress line on stderr while solving")
+	logPath := fs.String("log", "", "write solver trace events to a file, or - for stderr")
+
+	return func(ctx context.Context) (context.Context, func() error, error) {
+		var tracers []trace.Tracer
+		closers := []func() error{}
+
+		switch *logPath {
+		case "":
+		case "-":
+			tracers = append(tracers, trace.NewLog(os.Stderr))
+		default:
+			f, err := os.Create(*logPath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("creating trace log: %w", err)
+			}
+			tracers = append(tracers, trace.NewLog(f))
+			closers = append(closers, f.Close)
+		}
+
+		if *progress {
+			p := trace.NewProgress(os.Stderr, 100*time.Millisecond)
+			tracers = append(tracers, p)
+			// The progress line must be finished before anything else is printed.
+			closers = append([]func() error{p.Close}, closers...)
+		}
+
+		closeAll := func() error {
+			var firstErr error
+			for _, c := range closers {
+				if err := c(); err != nil && firstErr == nil {
+					firstErr = err
+				}
+			}
+			return firstErr
+		}
+
+		if len(tracers) == 0 {
+			return ctx, closeAll, nil
+		}
+		return trace.With(ctx, trace.Multi(tracers...)), closeAll, nil
+	}
+}
@@ -0,0 +1,14 @@
+// Package inputs holds the input store of the repository, one directory
+// of inputs and expected answers per account as described in
+// pkg/inputs.
+//
+// Building with -tags embedinputs embeds the store into the binary, so
+// that aoc can run every account's inputs without the repository checked
+// out.
+package inputs
+
+import "io/fs"
+
+// FS is the embedded input store, which is nil unless built with
+// -tags embedinputs.
+var FS fs.FS
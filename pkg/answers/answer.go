@@ -0,0 +1,151 @@
+package answers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindNone     Kind = ""
+	KindInt      Kind = "int"
+	KindString   Kind = "string"
+	KindRendered Kind = "rendered"
+)
+
+// Answer is the result of solving a puzzle part. It's either an integer,
+// a string (like day 5's crate letters) or a rendered multi-line screen
+// (like day 10's CRT output).
+type Answer struct {
+	kind Kind
+	i    int
+	s    string
+}
+
+// Value is the set of types an Answer can be created from with Of.
+type Value interface {
+	int | string
+}
+
+func Int(v int) Answer {
+	return Answer{kind: KindInt, i: v}
+}
+
+func String(s string) Answer {
+	return Answer{kind: KindString, s: s}
+}
+
+// Rendered creates an answer from rendered output, normalized so that
+// trailing whitespace and surrounding empty lines don't matter.
+func Rendered(screen string) Answer {
+	return Answer{kind: KindRendered, s: normalizeRendered(screen)}
+}
+
+func Of[T Value](v T) Answer {
+	if i, ok := any(v).(int); ok {
+		return Int(i)
+	}
+	return String(any(v).(string))
+}
+
+func (a Answer) Kind() Kind {
+	return a.kind
+}
+
+func (a Answer) IsZero() bool {
+	return a.kind == KindNone
+}
+
+func (a Answer) Int() (int, bool) {
+	return a.i, a.kind == KindInt
+}
+
+// Lines returns the answer's lines, which is mostly useful for rendered answers.
+func (a Answer) Lines() []string {
+	return strings.Split(a.String(), "\n")
+}
+
+func (a Answer) Equal(other Answer) bool {
+	return a == other
+}
+
+// String returns the normalized string form of the answer.
+func (a Answer) String() string {
+	switch a.kind {
+	case KindInt:
+		return strconv.Itoa(a.i)
+	case KindString, KindRendered:
+		return a.s
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON encodes integers as numbers, strings as strings and
+// rendered answers as an array of lines to keep them readable.
+func (a Answer) MarshalJSON() ([]byte, error) {
+	switch a.kind {
+	case KindInt:
+		return json.Marshal(a.i)
+	case KindString:
+		return json.Marshal(a.s)
+	case KindRendered:
+		return json.Marshal(a.Lines())
+	default:
+		return []byte("null"), nil
+	}
+}
+
+func (a *Answer) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return fmt.Errorf("empty answer")
+	}
+
+	switch data[0] {
+	case 'n':
+		*a = Answer{}
+		return nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("decoding string answer: %w", err)
+		}
+		*a = String(s)
+		return nil
+	case '[':
+		var lines []string
+		if err := json.Unmarshal(data, &lines); err != nil {
+			return fmt.Errorf("decoding rendered answer: %w", err)
+		}
+		*a = Rendered(strings.Join(lines, "\n"))
+		return nil
+	default:
+		var i int
+		if err := json.Unmarshal(data, &i); err != nil {
+			return fmt.Errorf("decoding int answer: %w", err)
+		}
+		*a = Int(i)
+		return nil
+	}
+}
+
+func normalizeRendered(screen string) string {
+	lines := strings.Split(strings.ReplaceAll(screen, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
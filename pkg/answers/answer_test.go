@@ -0,0 +1,67 @@
+package answers_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+)
+
+func TestAnswer(t *testing.T) {
+	screen := "\n##..\n#..#  \n"
+
+	t.Run("Equal", func(t *testing.T) {
+		tests := []struct {
+			name string
+			a, b answers.Answer
+			want bool
+		}{
+			{"same int", answers.Int(15), answers.Of(15), true},
+			{"different int", answers.Int(15), answers.Int(16), false},
+			{"same string", answers.String("CMZ"), answers.Of("CMZ"), true},
+			{"int and string", answers.Int(15), answers.String("15"), false},
+			{"normalized rendered", answers.Rendered(screen), answers.Rendered("##..\n#..#"), true},
+			{"string and rendered", answers.String("##..\n#..#"), answers.Rendered("##..\n#..#"), false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if got := tt.a.Equal(tt.b); got != tt.want {
+					t.Errorf("got %t, want %t", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		tests := []struct {
+			answer answers.Answer
+			want   string
+		}{
+			{answers.Int(24000), `24000`},
+			{answers.String("CMZ"), `"CMZ"`},
+			{answers.Rendered(screen), `["##..","#..#"]`},
+			{answers.Answer{}, `null`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.want, func(t *testing.T) {
+				b, err := json.Marshal(tt.answer)
+				if err != nil {
+					t.Fatalf("encoding: %v", err)
+				}
+				if got := string(b); got != tt.want {
+					t.Errorf("got %s, want %s", got, tt.want)
+				}
+
+				var decoded answers.Answer
+				if err := json.Unmarshal(b, &decoded); err != nil {
+					t.Fatalf("decoding: %v", err)
+				}
+				if !decoded.Equal(tt.answer) {
+					t.Errorf("got %v after round trip, want %v", decoded, tt.answer)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,183 @@
+// Package aocclient talks to the Advent of Code website, or anything
+// that looks like it, to download puzzle inputs and descriptions.
+package aocclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	Year           = 2022
+)
+
+type Client struct {
+	baseURL  string
+	session  string
+	cacheDir string
+
+	httpClient *http.Client
+}
+
+// NewClient creates a client authenticating with the session cookie value.
+// Downloaded files are cached in cacheDir per website and session, and
+// never downloaded again.
+func NewClient(baseURL, session, cacheDir string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		session:    session,
+		cacheDir:   cacheDir,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// CheckDay returns an error unless day is one of the 25 days of puzzles.
+func CheckDay(day int) error {
+	if day < 1 || day > 25 {
+		return fmt.Errorf("illegal day %d, must be between 1 and 25", day)
+	}
+	return nil
+}
+
+// CheckPart returns an error unless part is one of the two parts of a
+// day's puzzle.
+func CheckPart(part int) error {
+	if part != 1 && part != 2 {
+		return fmt.Errorf("illegal part %d, must be 1 or 2", part)
+	}
+	return nil
+}
+
+// DefaultCacheDir returns the aoc directory in the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc"), nil
+}
+
+// Input returns the day's puzzle input.
+func (c *Client) Input(ctx context.Context, day int) ([]byte, error) {
+	if err := CheckDay(day); err != nil {
+		return nil, err
+	}
+	return c.cached(day, "input.txt", func() ([]byte, error) {
+		return c.get(ctx, fmt.Sprintf("/%d/day/%d/input", Year, day))
+	})
+}
+
+// Instructions returns the day's puzzle description as plain text.
+// Part two is only included once part one has been solved, so a cached
+// description without it can be removed with ClearInstructions.
+func (c *Client) Instructions(ctx context.Context, day int) ([]byte, error) {
+	if err := CheckDay(day); err != nil {
+		return nil, err
+	}
+	return c.cached(day, "instructions.txt", func() ([]byte, error) {
+		page, err := c.get(ctx, fmt.Sprintf("/%d/day/%d", Year, day))
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := articlesToText(string(page))
+		if err != nil {
+			return nil, fmt.Errorf("converting puzzle page: %w", err)
+		}
+		return []byte(text), nil
+	})
+}
+
+func (c *Client) ClearInstructions(day int) error {
+	err := os.Remove(c.cachePath(day, "instructions.txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// account tells apart the accounts, and websites, the client talks to
+// without writing the session itself to disk.
+func (c *Client) account() string {
+	sum := sha256.Sum256([]byte(c.baseURL + "\n" + c.session))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (c *Client) cachePath(day int, filename string) string {
+	return filepath.Join(c.cacheDir, fmt.Sprint(Year), c.account(), fmt.Sprintf("day%d", day), filename)
+}
+
+func (c *Client) cached(day int, filename string, fetch func() ([]byte, error)) ([]byte, error) {
+	path := c.cachePath(day, filename)
+	if b, err := os.ReadFile(path); err == nil {
+		return b, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+
+	b, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache dir: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return nil, fmt.Errorf("writing cache: %w", err)
+	}
+
+	return b, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if c.session == "" {
+		return nil, errors.New("no session cookie set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.session})
+	req.Header.Set("User-Agent", "github.com/kristofferostlund/adventofcode-2022")
+
+	return req, nil
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(req)
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %s: %w", req.URL.Path, err)
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting %s: got status %d: %s", req.URL.Path, res.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	return b, nil
+}
@@ -0,0 +1,120 @@
+package aocclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/aocclient"
+)
+
+const session = "secret-session"
+
+// newServer is a stand-in for the Advent of Code website which counts
+// the requests it gets to verify the client's caching.
+func newServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+
+	page, err := os.ReadFile("testdata/day7.html")
+	if err != nil {
+		t.Fatalf("reading page: %v", err)
+	}
+
+	var requests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2022/day/7", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write(page)
+	})
+	mux.HandleFunc("/2022/day/7/input", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte("$ cd /\n$ ls\n"))
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != session {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Input", func(t *testing.T) {
+		server, requests := newServer(t)
+		client := aocclient.NewClient(server.URL, session, t.TempDir())
+
+		for i := 0; i < 2; i++ {
+			got, err := client.Input(ctx, 7)
+			if err != nil {
+				t.Fatalf("fetching input: %v", err)
+			}
+			if want := "$ cd /\n$ ls\n"; string(got) != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		}
+
+		if got, want := atomic.LoadInt32(requests), int32(1); got != want {
+			t.Errorf("got %d requests, want %d", got, want)
+		}
+	})
+
+	t.Run("Instructions", func(t *testing.T) {
+		server, requests := newServer(t)
+		client := aocclient.NewClient(server.URL, session, t.TempDir())
+
+		want, err := os.ReadFile("testdata/day7.txt")
+		if err != nil {
+			t.Fatalf("reading expected instructions: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			got, err := client.Instructions(ctx, 7)
+			if err != nil {
+				t.Fatalf("fetching instructions: %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		}
+
+		if got, want := atomic.LoadInt32(requests), int32(1); got != want {
+			t.Errorf("got %d requests, want %d", got, want)
+		}
+	})
+
+	t.Run("Input per account", func(t *testing.T) {
+		// Another website, or another session on the same one, has inputs
+		// of its own that the cache mustn't mix up.
+		cacheDir := t.TempDir()
+		for i := 0; i < 2; i++ {
+			server, requests := newServer(t)
+			client := aocclient.NewClient(server.URL, session, cacheDir)
+
+			if _, err := client.Input(ctx, 7); err != nil {
+				t.Fatalf("fetching input: %v", err)
+			}
+			if got, want := atomic.LoadInt32(requests), int32(1); got != want {
+				t.Errorf("got %d requests to website %d, want %d", got, i+1, want)
+			}
+		}
+	})
+
+	t.Run("bad session", func(t *testing.T) {
+		server, _ := newServer(t)
+		client := aocclient.NewClient(server.URL, "wrong", t.TempDir())
+
+		if _, err := client.Input(ctx, 7); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package aocclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+)
+
+type Submission struct {
+	Answer  answers.Answer `json:"answer"`
+	Verdict Verdict        `json:"verdict"`
+	At      time.Time      `json:"at"`
+}
+
+// History keeps track of the submitted answers per day and part,
+// so that answers we already know are wrong aren't submitted again.
+type History struct {
+	path        string
+	Submissions map[string][]Submission `json:"submissions"`
+}
+
+// LoadHistory reads the history at path. A missing file is an empty history.
+func LoadHistory(path string) (*History, error) {
+	h := &History{path: path, Submissions: make(map[string][]Submission)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+
+	if err := json.Unmarshal(b, h); err != nil {
+		return nil, fmt.Errorf("decoding history: %w", err)
+	}
+	return h, nil
+}
+
+// HistoryPath is where the history is kept, next to the cached downloads.
+// Every session has its own, as answers are wrong or right per account.
+func (c *Client) HistoryPath() string {
+	return filepath.Join(c.cacheDir, fmt.Sprint(Year), c.account(), "history.json")
+}
+
+func historyKey(day, part int) string {
+	return fmt.Sprintf("day%d-part%d", day, part)
+}
+
+func (h *History) Of(day, part int) []Submission {
+	return h.Submissions[historyKey(day, part)]
+}
+
+// Check returns an error if the answer shouldn't be submitted, either
+// because the part is solved, the answer is known to be wrong or it's
+// outside of the range given by earlier too high or too low answers.
+func (h *History) Check(day, part int, answer answers.Answer) error {
+	value, isInt := answer.Int()
+
+	for _, s := range h.Of(day, part) {
+		if s.Verdict == VerdictCorrect {
+			return fmt.Errorf("already solved with %s", s.Answer)
+		}
+
+		if s.Answer.Equal(answer) && s.Verdict.IsWrong() {
+			return fmt.Errorf("%s was already submitted and was %s", answer, s.Verdict)
+		}
+
+		prev, prevIsInt := s.Answer.Int()
+		if !isInt || !prevIsInt {
+			continue
+		}
+		if s.Verdict == VerdictTooHigh && value >= prev {
+			return fmt.Errorf("%d is too high, %d already was", value, prev)
+		}
+		if s.Verdict == VerdictTooLow && value <= prev {
+			return fmt.Errorf("%d is too low, %d already was", value, prev)
+		}
+	}
+
+	return nil
+}
+
+// Record adds the submission to the history and saves it.
+// Rate limited submissions say nothing about the answer and are skipped.
+func (h *History) Record(day, part int, answer answers.Answer, verdict Verdict) error {
+	if verdict == VerdictRateLimited || verdict == VerdictUnknown {
+		return nil
+	}
+
+	key := historyKey(day, part)
+	h.Submissions[key] = append(h.Submissions[key], Submission{
+		Answer:  answer,
+		Verdict: verdict,
+		At:      time.Now().UTC(),
+	})
+
+	return h.save()
+}
+
+func (h *History) save() error {
+	b, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("creating history dir: %w", err)
+	}
+	if err := os.WriteFile(h.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing history: %w", err)
+	}
+	return nil
+}
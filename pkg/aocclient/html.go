@@ -0,0 +1,162 @@
+package aocclient
+
+import (
+	"errors"
+	"html"
+	"strings"
+)
+
+// articlesToText converts the <article> elements of a puzzle page to the
+// plain text format of the instructions.txt files: headings and paragraphs
+// separated by blank lines, preformatted blocks kept as-is and list items
+// indented by four spaces per level.
+func articlesToText(page string) (string, error) {
+	blocks := make([]string, 0)
+
+	rest := page
+	found := false
+	for {
+		start := strings.Index(rest, "<article")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "</article>")
+		if end < 0 {
+			return "", errors.New("unterminated article")
+		}
+
+		found = true
+		blocks = append(blocks, articleBlocks(rest[start:start+end])...)
+		rest = rest[start+end+len("</article>"):]
+	}
+
+	if !found {
+		return "", errors.New("no article found in page")
+	}
+
+	return strings.Join(blocks, "\n\n") + "\n", nil
+}
+
+type textConverter struct {
+	blocks []string
+	lines  []string // lines of the current list, if any
+	sb     strings.Builder
+
+	inPre     bool
+	listLevel int
+}
+
+func articleBlocks(article string) []string {
+	c := &textConverter{}
+
+	for i := 0; i < len(article); {
+		if article[i] != '<' {
+			next := strings.IndexByte(article[i:], '<')
+			if next < 0 {
+				next = len(article) - i
+			}
+			c.text(article[i : i+next])
+			i += next
+			continue
+		}
+
+		end := strings.IndexByte(article[i:], '>')
+		if end < 0 {
+			break
+		}
+		c.tag(article[i+1 : i+end])
+		i += end + 1
+	}
+
+	c.flush()
+	return c.blocks
+}
+
+func (c *textConverter) text(raw string) {
+	text := html.UnescapeString(raw)
+	if c.inPre {
+		c.sb.WriteString(text)
+		return
+	}
+
+	// Outside of <pre>, whitespace works like it does in a browser.
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if collapsed == "" {
+		if text != "" && c.sb.Len() > 0 {
+			c.sb.WriteString(" ")
+		}
+		return
+	}
+	if startsWithSpace(text) && c.sb.Len() > 0 {
+		c.sb.WriteString(" ")
+	}
+	c.sb.WriteString(collapsed)
+	if endsWithSpace(text) {
+		c.sb.WriteString(" ")
+	}
+}
+
+func (c *textConverter) tag(raw string) {
+	closing := strings.HasPrefix(raw, "/")
+	name := strings.TrimPrefix(raw, "/")
+	if i := strings.IndexAny(name, " \t\n/"); i >= 0 {
+		name = name[:i]
+	}
+
+	switch strings.ToLower(name) {
+	case "h2", "p":
+		c.flush()
+	case "pre":
+		c.flush()
+		c.inPre = !closing
+	case "ul", "ol":
+		c.flush()
+		if closing {
+			c.listLevel--
+			if c.listLevel == 0 {
+				c.flushList()
+			}
+		} else {
+			c.listLevel++
+		}
+	case "li":
+		c.flush()
+	}
+}
+
+// flush ends the current block of text, which becomes a list item
+// when inside of a list.
+func (c *textConverter) flush() {
+	text := c.sb.String()
+	c.sb.Reset()
+
+	if c.inPre {
+		text = strings.TrimRight(text, "\n")
+	} else {
+		text = strings.TrimSpace(text)
+	}
+	if text == "" {
+		return
+	}
+
+	if c.listLevel > 0 {
+		c.lines = append(c.lines, strings.Repeat("    ", c.listLevel)+text)
+		return
+	}
+	c.blocks = append(c.blocks, text)
+}
+
+func (c *textConverter) flushList() {
+	if len(c.lines) > 0 {
+		c.blocks = append(c.blocks, strings.Join(c.lines, "\n"))
+	}
+	c.lines = nil
+}
+
+func startsWithSpace(s string) bool {
+	return s != "" && strings.TrimLeft(s[:1], " \t\n\r") == ""
+}
+
+func endsWithSpace(s string) bool {
+	return s != "" && strings.TrimRight(s[len(s)-1:], " \t\n\r") == ""
+}
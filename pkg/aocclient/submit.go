@@ -0,0 +1,105 @@
+package aocclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+)
+
+type Verdict string
+
+const (
+	VerdictCorrect       Verdict = "correct"
+	VerdictWrong         Verdict = "wrong"
+	VerdictTooHigh       Verdict = "too high"
+	VerdictTooLow        Verdict = "too low"
+	VerdictRateLimited   Verdict = "rate limited"
+	VerdictAlreadySolved Verdict = "already solved"
+	VerdictUnknown       Verdict = "unknown"
+)
+
+// IsWrong reports whether the verdict rules out the submitted answer.
+func (v Verdict) IsWrong() bool {
+	switch v {
+	case VerdictWrong, VerdictTooHigh, VerdictTooLow:
+		return true
+	default:
+		return false
+	}
+}
+
+type SubmitResult struct {
+	Verdict Verdict
+	// Wait is how long to wait before submitting again, if known.
+	Wait    time.Duration
+	Message string
+}
+
+// Submit posts the answer for the day's part and parses the verdict
+// from the response page.
+func (c *Client) Submit(ctx context.Context, day, part int, answer answers.Answer) (SubmitResult, error) {
+	if err := CheckDay(day); err != nil {
+		return SubmitResult{}, err
+	}
+	if err := CheckPart(part); err != nil {
+		return SubmitResult{}, err
+	}
+
+	form := url.Values{
+		"level":  {fmt.Sprint(part)},
+		"answer": {answer.String()},
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", Year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return SubmitResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	page, err := c.do(req)
+	if err != nil {
+		return SubmitResult{}, err
+	}
+
+	message, err := articlesToText(string(page))
+	if err != nil {
+		return SubmitResult{}, fmt.Errorf("reading response: %w", err)
+	}
+
+	return parseSubmitResult(strings.TrimSpace(message)), nil
+}
+
+var waitPattern = regexp.MustCompile(`You have ((?:\d+m ?)?(?:\d+s)?) left to wait`)
+
+func parseSubmitResult(message string) SubmitResult {
+	result := SubmitResult{Verdict: VerdictUnknown, Message: message}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		result.Verdict = VerdictCorrect
+	case strings.Contains(message, "You gave an answer too recently"):
+		result.Verdict = VerdictRateLimited
+	case strings.Contains(message, "your answer is too high"):
+		result.Verdict = VerdictTooHigh
+	case strings.Contains(message, "your answer is too low"):
+		result.Verdict = VerdictTooLow
+	case strings.Contains(message, "That's not the right answer"):
+		result.Verdict = VerdictWrong
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		result.Verdict = VerdictAlreadySolved
+	}
+
+	if m := waitPattern.FindStringSubmatch(message); m != nil {
+		if wait, err := time.ParseDuration(strings.ReplaceAll(m[1], " ", "")); err == nil {
+			result.Wait = wait
+		}
+	}
+
+	return result
+}
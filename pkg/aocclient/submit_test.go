@@ -0,0 +1,190 @@
+package aocclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/aocclient"
+)
+
+// The responses are trimmed down versions of what the website responds with.
+var submitResponses = map[string]string{
+	"100": `<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. <a href="/2022/day/1">[Return to Day 1]</a></p></article>`,
+	"10":  `<article><p>That's not the right answer; your answer is too low.  Please wait one minute before trying again. <a href="/2022/day/1">[Return to Day 1]</a></p></article>`,
+	"ABC": `<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data. <a href="/2022/day/1">[Return to Day 1]</a></p></article>`,
+	"50":  `<article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to collecting enough star fruit. <a href="/2022/day/1#part2">[Continue to Part Two]</a></p></article>`,
+	"42":  `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 4s left to wait. <a href="/2022/day/1">[Return to Day 1]</a></p></article>`,
+}
+
+func newSubmitServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2022/day/1/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("level") != "1" {
+			http.Error(w, "bad form", http.StatusBadRequest)
+			return
+		}
+
+		response, ok := submitResponses[r.PostForm.Get("answer")]
+		if !ok {
+			http.Error(w, "unexpected answer", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, "<html><body><main>%s</main></body></html>", response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSubmit(t *testing.T) {
+	ctx := context.Background()
+	server := newSubmitServer(t)
+	client := aocclient.NewClient(server.URL, session, t.TempDir())
+
+	tests := []struct {
+		answer      answers.Answer
+		wantVerdict aocclient.Verdict
+		wantWait    time.Duration
+	}{
+		{answers.Int(100), aocclient.VerdictTooHigh, 0},
+		{answers.Int(10), aocclient.VerdictTooLow, 0},
+		{answers.String("ABC"), aocclient.VerdictWrong, 0},
+		{answers.Int(50), aocclient.VerdictCorrect, 0},
+		{answers.Int(42), aocclient.VerdictRateLimited, time.Minute + 4*time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer.String(), func(t *testing.T) {
+			got, err := client.Submit(ctx, 1, 1, tt.answer)
+			if err != nil {
+				t.Fatalf("submitting: %v", err)
+			}
+			if got.Verdict != tt.wantVerdict {
+				t.Errorf("got verdict %q, want %q (message %q)", got.Verdict, tt.wantVerdict, got.Message)
+			}
+			if got.Wait != tt.wantWait {
+				t.Errorf("got wait %s, want %s", got.Wait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestSubmit_illegalPuzzle(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	t.Cleanup(server.Close)
+	client := aocclient.NewClient(server.URL, session, t.TempDir())
+
+	for _, puzzle := range [][2]int{{0, 1}, {26, 1}, {1, 0}, {1, 3}} {
+		if _, err := client.Submit(context.Background(), puzzle[0], puzzle[1], answers.Int(5)); err == nil {
+			t.Errorf("got no error submitting day %d part %d", puzzle[0], puzzle[1])
+		}
+	}
+	if requests != 0 {
+		t.Errorf("got %d requests, want none", requests)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	history, err := aocclient.LoadHistory(path)
+	if err != nil {
+		t.Fatalf("loading history: %v", err)
+	}
+
+	records := []struct {
+		answer  answers.Answer
+		verdict aocclient.Verdict
+	}{
+		{answers.Int(100), aocclient.VerdictTooHigh},
+		{answers.Int(10), aocclient.VerdictTooLow},
+		{answers.Int(42), aocclient.VerdictWrong},
+		{answers.Int(43), aocclient.VerdictRateLimited},
+	}
+	for _, r := range records {
+		if err := history.Record(1, 1, r.answer, r.verdict); err != nil {
+			t.Fatalf("recording: %v", err)
+		}
+	}
+
+	// Reloading makes sure the history survives between runs.
+	history, err = aocclient.LoadHistory(path)
+	if err != nil {
+		t.Fatalf("reloading history: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		part      int
+		answer    answers.Answer
+		wantBlock bool
+	}{
+		{"known wrong", 1, answers.Int(42), true},
+		{"above too high", 1, answers.Int(150), true},
+		{"equal to too high", 1, answers.Int(100), true},
+		{"below too low", 1, answers.Int(5), true},
+		{"rate limited is not wrong", 1, answers.Int(43), false},
+		{"within range", 1, answers.Int(50), false},
+		{"string answers skip the range", 1, answers.String("ABC"), false},
+		{"other part", 2, answers.Int(42), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := history.Check(1, tt.part, tt.answer)
+			if gotBlock := err != nil; gotBlock != tt.wantBlock {
+				t.Errorf("got blocked %t, want %t (err: %v)", gotBlock, tt.wantBlock, err)
+			}
+		})
+	}
+
+	if err := history.Record(1, 1, answers.Int(50), aocclient.VerdictCorrect); err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+	if err := history.Check(1, 1, answers.Int(51)); err == nil {
+		t.Errorf("expected a solved part to block further submissions")
+	}
+}
+
+func TestClient_HistoryPath(t *testing.T) {
+	dir := t.TempDir()
+	alice := aocclient.NewClient(aocclient.DefaultBaseURL, "alice", dir)
+	bob := aocclient.NewClient(aocclient.DefaultBaseURL, "bob", dir)
+
+	if alice.HistoryPath() == bob.HistoryPath() {
+		t.Fatalf("got the same history for both sessions: %s", alice.HistoryPath())
+	}
+	if again := aocclient.NewClient(aocclient.DefaultBaseURL, "alice", dir); again.HistoryPath() != alice.HistoryPath() {
+		t.Errorf("got history %s for the same session, want %s", again.HistoryPath(), alice.HistoryPath())
+	}
+
+	history, err := aocclient.LoadHistory(alice.HistoryPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Record(1, 1, answers.Int(42), aocclient.VerdictWrong); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err = aocclient.LoadHistory(bob.HistoryPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Check(1, 1, answers.Int(42)); err != nil {
+		t.Errorf("got %v for bob, want alice's wrong answer to not count", err)
+	}
+}
@@ -0,0 +1,87 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type Baseline struct {
+	Results []Result `json:"results"`
+}
+
+func LoadBaseline(path string) (Baseline, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Baseline{}, fmt.Errorf("reading baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(b, &baseline); err != nil {
+		return Baseline{}, fmt.Errorf("decoding baseline: %w", err)
+	}
+	return baseline, nil
+}
+
+// Save writes the baseline to path, keeping any results from an existing
+// baseline that weren't part of this run.
+func (b Baseline) Save(path string) error {
+	merged := b
+	if existing, err := LoadBaseline(path); err == nil {
+		seen := make(map[string]struct{}, len(b.Results))
+		for _, r := range b.Results {
+			seen[r.ID()] = struct{}{}
+		}
+
+		merged.Results = make([]Result, 0, len(existing.Results)+len(b.Results))
+		for _, r := range existing.Results {
+			if _, ok := seen[r.ID()]; !ok {
+				merged.Results = append(merged.Results, r)
+			}
+		}
+		merged.Results = append(merged.Results, b.Results...)
+	}
+
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing baseline: %w", err)
+	}
+	return nil
+}
+
+type Comparison struct {
+	Current Result
+	Base    Result
+	HasBase bool
+	// Delta is the relative change in ns/op, 0.1 means 10% slower.
+	Delta float64
+}
+
+// IsRegression reports whether the current run is slower than the base
+// by more than the threshold, where 0.1 means 10% slower.
+func (c Comparison) IsRegression(threshold float64) bool {
+	return c.HasBase && c.Delta > threshold
+}
+
+func (b Baseline) Compare(current []Result) []Comparison {
+	lookup := make(map[string]Result, len(b.Results))
+	for _, r := range b.Results {
+		lookup[r.ID()] = r
+	}
+
+	comparisons := make([]Comparison, 0, len(current))
+	for _, r := range current {
+		c := Comparison{Current: r}
+		if base, ok := lookup[r.ID()]; ok && base.NsPerOp > 0 {
+			c.Base = base
+			c.HasBase = true
+			c.Delta = float64(r.NsPerOp-base.NsPerOp) / float64(base.NsPerOp)
+		}
+		comparisons = append(comparisons, c)
+	}
+
+	return comparisons
+}
@@ -0,0 +1,156 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+type Result struct {
+	Key         registry.Key `json:"key"`
+	Input       string       `json:"input"`
+	Iterations  int          `json:"iterations"`
+	NsPerOp     int64        `json:"nsPerOp"`
+	AllocsPerOp uint64       `json:"allocsPerOp"`
+	BytesPerOp  uint64       `json:"bytesPerOp"`
+	PeakHeap    uint64       `json:"peakHeapBytes"`
+}
+
+// ID identifies the result across runs.
+func (r Result) ID() string {
+	return fmt.Sprintf("%s %s", r.Key, r.Input)
+}
+
+// Measure runs the solver on the input until at least minDuration has
+// passed, and at least once, and reports the averages per run. The peak
+// heap is measured on a separate first run as the largest amount of heap
+// in use on top of what was live before it.
+func Measure(key registry.Key, solver registry.Solver, input string, data []byte, minDuration time.Duration) (Result, error) {
+	peak, err := PeakHeap(solver, bytes.NewReader(data))
+	if err != nil {
+		return Result{}, fmt.Errorf("solving %s: %w", key, err)
+	}
+
+	runtime.GC()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	iterations := 0
+	start := time.Now()
+	for iterations == 0 || time.Since(start) < minDuration {
+		if _, err := solver.Solve(context.Background(), bytes.NewReader(data)); err != nil {
+			return Result{}, fmt.Errorf("solving %s: %w", key, err)
+		}
+		iterations++
+	}
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+
+	n := uint64(iterations)
+	return Result{
+		Key:         key,
+		Input:       input,
+		Iterations:  iterations,
+		NsPerOp:     elapsed.Nanoseconds() / int64(iterations),
+		AllocsPerOp: (after.Mallocs - before.Mallocs) / n,
+		BytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / n,
+		PeakHeap:    peak,
+	}, nil
+}
+
+// PeakHeap solves the input once and returns the largest amount of heap
+// in use while solving, on top of what was live before. Reading the input
+// from a stream instead of memory shows how much the solver itself holds
+// on to.
+func PeakHeap(solver registry.Solver, reader io.Reader) (uint64, error) {
+	runtime.GC()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	sampler := startHeapSampler(time.Millisecond)
+	_, err := solver.Solve(context.Background(), reader)
+	peak := sampler.stop()
+	if err != nil {
+		return 0, err
+	}
+
+	// The sampled metric doesn't include what's cached per P,
+	// which matters for short runs that never trigger a GC.
+	runtime.ReadMemStats(&after)
+	if after.HeapAlloc > peak {
+		peak = after.HeapAlloc
+	}
+
+	if peak < before.HeapAlloc {
+		return 0, nil
+	}
+	return peak - before.HeapAlloc, nil
+}
+
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+type heapSampler struct {
+	done    chan struct{}
+	wg      sync.WaitGroup
+	samples []metrics.Sample
+	peak    uint64
+}
+
+// startHeapSampler polls the heap size in the background since
+// runtime.MemStats only gives us the current value, not the peak.
+func startHeapSampler(interval time.Duration) *heapSampler {
+	s := &heapSampler{
+		done:    make(chan struct{}),
+		samples: []metrics.Sample{{Name: heapObjectsMetric}},
+	}
+
+	s.sample()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-ticker.C:
+				s.sample()
+			}
+		}
+	}()
+
+	return s
+}
+
+func (s *heapSampler) sample() {
+	metrics.Read(s.samples)
+	if s.samples[0].Value.Kind() != metrics.KindUint64 {
+		return
+	}
+	if v := s.samples[0].Value.Uint64(); v > s.peak {
+		s.peak = v
+	}
+}
+
+// stop stops the sampling and returns the peak. A last sample is taken
+// so that runs shorter than the interval are measured too.
+func (s *heapSampler) stop() uint64 {
+	close(s.done)
+	s.wg.Wait()
+	s.sample()
+	return s.peak
+}
@@ -0,0 +1,132 @@
+package bench_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/bench"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/all"
+)
+
+func TestMeasure(t *testing.T) {
+	key := registry.Key{Day: 1, Part: 1, Variant: registry.DefaultVariant}
+	solver := registry.Of(func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		return len(b), err
+	})
+
+	result, err := bench.Measure(key, solver, "input.txt", []byte("hello"), 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("measuring: %v", err)
+	}
+	if result.Iterations < 1 {
+		t.Errorf("got %d iterations, want at least 1", result.Iterations)
+	}
+	if result.NsPerOp <= 0 {
+		t.Errorf("got %d ns/op, want more than 0", result.NsPerOp)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	keyOf := func(day int) registry.Key {
+		return registry.Key{Day: day, Part: 1, Variant: registry.DefaultVariant}
+	}
+	path := filepath.Join(t.TempDir(), "bench.json")
+
+	first := bench.Baseline{Results: []bench.Result{
+		{Key: keyOf(1), Input: "input.txt", NsPerOp: 100},
+		{Key: keyOf(2), Input: "input.txt", NsPerOp: 100},
+	}}
+	if err := first.Save(path); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+
+	// Saving only day 2 keeps day 1 from the previous baseline.
+	second := bench.Baseline{Results: []bench.Result{
+		{Key: keyOf(2), Input: "input.txt", NsPerOp: 200},
+	}}
+	if err := second.Save(path); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+
+	baseline, err := bench.LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("loading: %v", err)
+	}
+	if got, want := len(baseline.Results), 2; got != want {
+		t.Fatalf("got %d results, want %d", got, want)
+	}
+
+	comparisons := baseline.Compare([]bench.Result{
+		{Key: keyOf(1), Input: "input.txt", NsPerOp: 105},
+		{Key: keyOf(2), Input: "input.txt", NsPerOp: 300},
+		{Key: keyOf(3), Input: "input.txt", NsPerOp: 300},
+	})
+
+	wantRegressions := []bool{false, true, false}
+	for i, c := range comparisons {
+		if got, want := c.IsRegression(0.1), wantRegressions[i]; got != want {
+			t.Errorf("%s: got regression %t, want %t (delta %.2f)", c.Current.ID(), got, want, c.Delta)
+		}
+	}
+	if comparisons[2].HasBase {
+		t.Errorf("got a base for a result missing from the baseline")
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func TestPeakHeap_streamVariants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("solves inputs of tens of megabytes")
+	}
+
+	// Sizes giving inputs of about 16MB and up, although day 6 part 1 finds
+	// its marker long before the end.
+	sizes := map[int]int{1: 500_000, 6: 16 << 20, 10: 3 << 20}
+	const maxPeak = 8 << 20
+
+	for _, key := range registry.Keys() {
+		if key.Variant != "stream" {
+			continue
+		}
+		key := key
+		t.Run(key.String(), func(t *testing.T) {
+			size, ok := sizes[key.Day]
+			if !ok {
+				t.Fatalf("no input size for day %d", key.Day)
+			}
+
+			input, err := gen.Reader(key.Day, 1, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer input.Close()
+			counter := &countingReader{r: input}
+
+			solver, _ := registry.Lookup(key.Day, key.Part, key.Variant)
+			peak, err := bench.PeakHeap(solver, counter)
+			if err != nil {
+				t.Fatalf("solving: %v", err)
+			}
+			t.Logf("peak heap of %dkB solving %dMB", peak>>10, counter.n>>20)
+			if peak > maxPeak {
+				t.Errorf("got a peak heap of %d bytes, want at most %d", peak, maxPeak)
+			}
+		})
+	}
+}
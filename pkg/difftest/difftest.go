@@ -0,0 +1,228 @@
+// Package difftest runs two solvers for the same day and part on
+// generated inputs to find where their answers differ, like an optimized
+// rewrite and the brute-force version it replaces.
+//
+// The first input they disagree on is shrunk to as small an input as
+// they still disagree on in the same way, which is usually small enough
+// to debug by hand.
+package difftest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+// Config is the two solvers to compare and the inputs to compare them on.
+type Config struct {
+	A, B registry.Key
+	// Seeds is the number of inputs to generate, from the seeds starting
+	// at FirstSeed.
+	Seeds     int
+	FirstSeed int64
+	Size      int
+	// Timeout is how long each solve gets, 0 meaning no timeout. Solvers
+	// ignoring the context are left running in the background when they
+	// time out.
+	Timeout time.Duration
+}
+
+// Outcome is what a solver made of an input.
+type Outcome struct {
+	Answer answers.Answer
+	Err    error
+}
+
+// Agrees reports whether the outcomes are the same answer or both errors.
+// Errors only have to both be there as different solvers report the same
+// problem in different ways.
+func (o Outcome) Agrees(other Outcome) bool {
+	return Compare(o, other) == Agreement
+}
+
+// Disagreement is the way two outcomes disagree, if they do.
+type Disagreement int
+
+const (
+	Agreement Disagreement = iota
+	// DifferentAnswers is both solvers answering, but not the same.
+	DifferentAnswers
+	// OnlyAErrs is the first solver failing on an input the second one
+	// answers, like a stricter parser, and OnlyBErrs the other way around.
+	OnlyAErrs
+	OnlyBErrs
+)
+
+func (d Disagreement) String() string {
+	switch d {
+	case Agreement:
+		return "agreement"
+	case DifferentAnswers:
+		return "different answers"
+	case OnlyAErrs:
+		return "only A errs"
+	case OnlyBErrs:
+		return "only B errs"
+	default:
+		return fmt.Sprintf("Disagreement(%d)", int(d))
+	}
+}
+
+// Compare returns the way the outcomes a and b disagree.
+func Compare(a, b Outcome) Disagreement {
+	switch {
+	case a.Err != nil && b.Err != nil:
+		return Agreement
+	case a.Err != nil:
+		return OnlyAErrs
+	case b.Err != nil:
+		return OnlyBErrs
+	case !a.Answer.Equal(b.Answer):
+		return DifferentAnswers
+	default:
+		return Agreement
+	}
+}
+
+func (o Outcome) String() string {
+	if o.Err != nil {
+		return fmt.Sprintf("error %q", o.Err)
+	}
+	return o.Answer.String()
+}
+
+// Mismatch is an input the solvers disagree on.
+type Mismatch struct {
+	A, B registry.Key
+	Seed int64
+	// Original is the generated input and Input the shrunk one.
+	Original []byte
+	Input    []byte
+	// OutcomeA and OutcomeB are what the solvers made of Input, which
+	// disagree in the same way as they did on Original.
+	OutcomeA Outcome
+	OutcomeB Outcome
+	Kind     Disagreement
+}
+
+func (m *Mismatch) String() string {
+	return fmt.Sprintf(
+		"%s gives %s and %s gives %s for the input from seed %d, shrunk from %d to %d bytes:\n%s",
+		m.A, m.OutcomeA, m.B, m.OutcomeB, m.Seed, len(m.Original), len(m.Input), m.Input,
+	)
+}
+
+// Find solves the inputs generated for the day of the config's keys with
+// both solvers, and returns the first input they disagree on after
+// shrinking it, or nil if they agree on all of them.
+func Find(ctx context.Context, cfg Config) (*Mismatch, error) {
+	if cfg.A.Day != cfg.B.Day {
+		return nil, fmt.Errorf("can't compare solvers for different days, %s and %s", cfg.A, cfg.B)
+	}
+	a, ok := registry.Lookup(cfg.A.Day, cfg.A.Part, cfg.A.Variant)
+	if !ok {
+		return nil, fmt.Errorf("no solver registered for %s", cfg.A)
+	}
+	b, ok := registry.Lookup(cfg.B.Day, cfg.B.Part, cfg.B.Variant)
+	if !ok {
+		return nil, fmt.Errorf("no solver registered for %s", cfg.B)
+	}
+
+	compare := func(input []byte) (Outcome, Outcome, Disagreement) {
+		outcomeA := Solve(ctx, a, input, cfg.Timeout)
+		outcomeB := Solve(ctx, b, input, cfg.Timeout)
+		return outcomeA, outcomeB, Compare(outcomeA, outcomeB)
+	}
+
+	for i := 0; i < cfg.Seeds; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("compared %d of %d inputs: %w", i, cfg.Seeds, err)
+		}
+
+		seed := cfg.FirstSeed + int64(i)
+		input, err := gen.Generate(cfg.A.Day, seed, cfg.Size)
+		if err != nil {
+			return nil, err
+		}
+		_, _, kind := compare(input)
+		if kind == Agreement {
+			continue
+		}
+
+		// Shrinking an input with different answers into one that a
+		// stricter parser rejects would trade the bug for another one.
+		shrunk := Shrink(input, func(candidate []byte) bool {
+			_, _, k := compare(candidate)
+			return k == kind
+		})
+		outcomeA, outcomeB, _ := compare(shrunk)
+		return &Mismatch{
+			A:        cfg.A,
+			B:        cfg.B,
+			Seed:     seed,
+			Original: input,
+			Input:    shrunk,
+			OutcomeA: outcomeA,
+			OutcomeB: outcomeB,
+			Kind:     kind,
+		}, nil
+	}
+	return nil, nil
+}
+
+// Solve solves the input, turning panics into errors and giving up after
+// timeout if it's positive.
+func Solve(ctx context.Context, solver registry.Solver, input []byte, timeout time.Duration) Outcome {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan Outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- Outcome{Err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+
+		answer, err := solver.Solve(ctx, bytes.NewReader(input))
+		done <- Outcome{Answer: answer, Err: err}
+	}()
+
+	select {
+	case outcome := <-done:
+		return outcome
+	case <-ctx.Done():
+		return Outcome{Err: fmt.Errorf("gave up solving: %w", ctx.Err())}
+	}
+}
+
+// Run fails the test with the first input that the variants of the day
+// and part disagree on, out of the inputs generated from the given number
+// of seeds.
+func Run(t *testing.T, day, part int, variantA, variantB string, seeds, size int) {
+	t.Helper()
+
+	m, err := Find(context.Background(), Config{
+		A:         registry.Key{Day: day, Part: part, Variant: variantA},
+		B:         registry.Key{Day: day, Part: part, Variant: variantB},
+		Seeds:     seeds,
+		FirstSeed: 1,
+		Size:      size,
+		Timeout:   10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("comparing solvers: %v", err)
+	}
+	if m != nil {
+		t.Errorf("%s", m)
+	}
+}
@@ -0,0 +1,135 @@
+package difftest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/difftest"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func TestFind(t *testing.T) {
+	// Using a made up day to not collide with any real registrations.
+	const day = 401
+
+	gen.Register(day, func(r *rand.Rand, w io.Writer, size int) error {
+		for i := 0; i < size; i++ {
+			fmt.Fprintln(w, r.Intn(1000))
+		}
+		return nil
+	})
+
+	lineCount := func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		return bytes.Count(b, []byte("\n")), err
+	}
+	registry.Register(day, 1, registry.Of(lineCount))
+	registry.RegisterVariant(day, 1, "same", registry.Of(lineCount))
+	registry.RegisterVariant(day, 1, "sevens", registry.Of(func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		// Off by one as soon as there's a 7 anywhere.
+		return bytes.Count(b, []byte("\n")) + bytes.Count(b, []byte("7")), err
+	}))
+	registry.RegisterVariant(day, 1, "strict", registry.Of(func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		if !bytes.HasSuffix(b, []byte("\n")) {
+			return 0, errors.New("no newline at the end")
+		}
+		return bytes.Count(b, []byte("\n")) + bytes.Count(b, []byte("7")), err
+	}))
+	registry.RegisterVariant(day, 1, "panics", registry.Of(func(reader io.Reader) (int, error) {
+		panic("oh no")
+	}))
+	registry.RegisterVariant(day, 1, "errors", registry.Of(func(reader io.Reader) (int, error) {
+		return 0, errors.New("oh no")
+	}))
+
+	cfg := func(variantA, variantB string) difftest.Config {
+		return difftest.Config{
+			A:         registry.Key{Day: day, Part: 1, Variant: variantA},
+			B:         registry.Key{Day: day, Part: 1, Variant: variantB},
+			Seeds:     20,
+			FirstSeed: 1,
+			Size:      50,
+		}
+	}
+
+	t.Run("Agreeing", func(t *testing.T) {
+		m, err := difftest.Find(context.Background(), cfg(registry.DefaultVariant, "same"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m != nil {
+			t.Errorf("got a mismatch, want none: %s", m)
+		}
+	})
+
+	t.Run("Disagreeing", func(t *testing.T) {
+		m, err := difftest.Find(context.Background(), cfg(registry.DefaultVariant, "sevens"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m == nil {
+			t.Fatal("got no mismatch, want one")
+		}
+		if got, want := string(m.Input), "7"; got != want {
+			t.Errorf("got input %q shrunk from %q, want %q", got, m.Original, want)
+		}
+		if m.OutcomeA.Agrees(m.OutcomeB) {
+			t.Errorf("got agreeing outcomes %s and %s", m.OutcomeA, m.OutcomeB)
+		}
+	})
+
+	t.Run("Same disagreement", func(t *testing.T) {
+		// Dropping the last newline makes strict fail instead, which is a
+		// disagreement too but not the one being shrunk.
+		m, err := difftest.Find(context.Background(), cfg(registry.DefaultVariant, "strict"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m == nil {
+			t.Fatal("got no mismatch, want one")
+		}
+		if got, want := string(m.Input), "7\n"; got != want {
+			t.Errorf("got input %q shrunk from %q, want %q", got, m.Original, want)
+		}
+		if m.Kind != difftest.DifferentAnswers || difftest.Compare(m.OutcomeA, m.OutcomeB) != m.Kind {
+			t.Errorf("got %s with outcomes %s and %s, want different answers", m.Kind, m.OutcomeA, m.OutcomeB)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		m, err := difftest.Find(context.Background(), cfg("panics", "errors"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m != nil {
+			t.Errorf("got a mismatch, want errors to agree: %s", m)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := difftest.Find(ctx, cfg(registry.DefaultVariant, "sevens")); !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestShrink(t *testing.T) {
+	input := []byte("a\nbxb\nc\ny\nd\n")
+	failing := func(input []byte) bool {
+		return bytes.Contains(input, []byte("x")) && bytes.Contains(input, []byte("y"))
+	}
+
+	if got, want := string(difftest.Shrink(input, failing)), "xy"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package difftest
+
+import "bytes"
+
+// Shrink returns the smallest input it can find that is still failing,
+// by removing ever smaller chunks of lines, and then of characters within
+// each remaining line, for as long as the input keeps failing. The input
+// itself must be failing.
+func Shrink(input []byte, failing func(input []byte) bool) []byte {
+	lines := bytes.SplitAfter(input, []byte("\n"))
+	lines = shrinkUnits(lines, func(lines [][]byte) bool {
+		return failing(bytes.Join(lines, nil))
+	})
+
+	for i, line := range lines {
+		before, after := bytes.Join(lines[:i], nil), bytes.Join(lines[i+1:], nil)
+
+		chars := make([][]byte, 0, len(line))
+		for j := range line {
+			chars = append(chars, line[j:j+1])
+		}
+		chars = shrinkUnits(chars, func(chars [][]byte) bool {
+			return failing(bytes.Join([][]byte{before, bytes.Join(chars, nil), after}, nil))
+		})
+		lines[i] = bytes.Join(chars, nil)
+	}
+
+	return bytes.Join(lines, nil)
+}
+
+// shrinkUnits removes chunks of units while they keep failing, starting
+// with halves and going down to single units, like delta debugging does.
+func shrinkUnits(units [][]byte, failing func(units [][]byte) bool) [][]byte {
+	chunks := 2
+	for len(units) > 0 {
+		if chunks > len(units) {
+			chunks = len(units)
+		}
+		size := (len(units) + chunks - 1) / chunks
+
+		removed := false
+		for start := 0; start < len(units); start += size {
+			end := start + size
+			if end > len(units) {
+				end = len(units)
+			}
+
+			candidate := make([][]byte, 0, len(units)-(end-start))
+			candidate = append(candidate, units[:start]...)
+			candidate = append(candidate, units[end:]...)
+			if failing(candidate) {
+				units = candidate
+				removed = true
+				break
+			}
+		}
+
+		switch {
+		case removed && chunks > 2:
+			chunks--
+		case removed:
+		case size == 1:
+			// Not a single unit can be removed.
+			return units
+		default:
+			chunks *= 2
+		}
+	}
+	return units
+}
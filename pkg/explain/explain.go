@@ -0,0 +1,94 @@
+// Package explain lets solvers say how they got to their answer, like the
+// route they took or the directory they picked, for when an input gives
+// a surprising answer.
+//
+// Explaining is opt-in per solve. Solvers look for an explanation in their
+// context with From and only add to it if there is one, so they don't pay
+// for keeping track of what they'd explain otherwise.
+package explain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Entry is one named part of an explanation, like the route or its
+// length. Values may span several lines, like a rendered grid.
+type Entry struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Explanation collects the entries a solver explains its answer with, in
+// the order they were added. A nil explanation discards everything added
+// to it, so solvers can add to whatever From returns.
+type Explanation struct {
+	mutex   sync.Mutex
+	entries []Entry
+}
+
+type contextKey struct{}
+
+// With returns a copy of ctx carrying a new explanation for the solver to
+// add to.
+func With(ctx context.Context) (context.Context, *Explanation) {
+	e := &Explanation{}
+	return context.WithValue(ctx, contextKey{}, e), e
+}
+
+// From returns the explanation carried by ctx, or nil if the solver isn't
+// asked to explain itself.
+func From(ctx context.Context) *Explanation {
+	e, _ := ctx.Value(contextKey{}).(*Explanation)
+	return e
+}
+
+// Add adds an entry to the explanation.
+func (e *Explanation) Add(name, value string) {
+	if e == nil {
+		return
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.entries = append(e.entries, Entry{Name: name, Value: value})
+}
+
+// Addf adds an entry with a value formatted like fmt.Sprintf.
+func (e *Explanation) Addf(name, format string, args ...any) {
+	if e == nil {
+		return
+	}
+	e.Add(name, fmt.Sprintf(format, args...))
+}
+
+func (e *Explanation) Entries() []Entry {
+	if e == nil {
+		return nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]Entry(nil), e.entries...)
+}
+
+// String formats the entries one per line, with multi-line values on
+// indented lines of their own.
+func (e *Explanation) String() string {
+	sb := &strings.Builder{}
+	for _, entry := range e.Entries() {
+		value := strings.TrimRight(entry.Value, "\n")
+		if !strings.Contains(value, "\n") {
+			fmt.Fprintf(sb, "%s: %s\n", entry.Name, value)
+			continue
+		}
+
+		fmt.Fprintf(sb, "%s:\n", entry.Name)
+		for _, line := range strings.Split(value, "\n") {
+			fmt.Fprintf(sb, "\t%s\n", line)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,40 @@
+package explain_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
+)
+
+func TestFrom(t *testing.T) {
+	if got := explain.From(context.Background()); got != nil {
+		t.Errorf("got %v, want no explanation", got)
+	}
+
+	ctx, e := explain.With(context.Background())
+	if got := explain.From(ctx); got != e {
+		t.Errorf("got %v, want %v", got, e)
+	}
+
+	// Adding to no explanation is fine.
+	explain.From(context.Background()).Add("ignored", "value")
+}
+
+func TestExplanation(t *testing.T) {
+	_, e := explain.With(context.Background())
+	e.Add("route", "S>>v\n...E\n")
+	e.Addf("steps", "%d", 31)
+
+	want := strings.Join([]string{
+		"route:",
+		"\tS>>v",
+		"\t...E",
+		"steps: 31",
+		"",
+	}, "\n")
+	if got := e.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
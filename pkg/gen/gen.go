@@ -0,0 +1,125 @@
+// Package gen generates random, valid puzzle inputs of any size, to stress
+// and benchmark the solvers well beyond a single input.txt.
+//
+// Each day registers a generator alongside its solvers, and a generator
+// only produces inputs that the day's default solvers can solve.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Generator writes a random input for a day to w, using only r for its
+// randomness so the same seed always gives the same input. Size scales
+// the input, like the number of lines or the side of a grid, and what it
+// means differs between days. Sizes too small to make a valid input are
+// rounded up.
+type Generator func(r *rand.Rand, w io.Writer, size int) error
+
+var (
+	mutex      sync.RWMutex
+	generators = make(map[int]Generator)
+)
+
+// Register registers the generator for the day.
+// Like registry.Register, it panics if called twice for the same day.
+func Register(day int, g Generator) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if g == nil {
+		panic(fmt.Sprintf("gen: nil generator for day %d", day))
+	}
+	if _, exists := generators[day]; exists {
+		panic(fmt.Sprintf("gen: day %d registered twice", day))
+	}
+	generators[day] = g
+}
+
+func Lookup(day int) (Generator, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	g, ok := generators[day]
+	return g, ok
+}
+
+// Days returns the days with a generator in order.
+func Days() []int {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	days := make([]int, 0, len(generators))
+	for day := range generators {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Generate returns the input generated for the day from seed.
+func Generate(day int, seed int64, size int) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := Write(buf, day, seed, size); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write writes the input generated for the day from seed to w as it's
+// generated, for inputs too large to keep in memory.
+func Write(w io.Writer, day int, seed int64, size int) error {
+	g, ok := Lookup(day)
+	if !ok {
+		return fmt.Errorf("no generator registered for day %d", day)
+	}
+
+	if err := g(rand.New(rand.NewSource(seed)), w, size); err != nil {
+		return fmt.Errorf("generating day %d with seed %d: %w", day, seed, err)
+	}
+	return nil
+}
+
+// Reader returns a reader of the input generated for the day from seed,
+// which is generated as it's read. The reader must be closed, after which
+// the generator is stopped the next time it writes.
+func Reader(day int, seed int64, size int) (io.ReadCloser, error) {
+	if _, ok := Lookup(day); !ok {
+		return nil, fmt.Errorf("no generator registered for day %d", day)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				s, ok := v.(stopped)
+				if !ok {
+					panic(v)
+				}
+				pw.CloseWithError(s.err)
+			}
+		}()
+		pw.CloseWithError(Write(stoppingWriter{pw}, day, seed, size))
+	}()
+	return pr, nil
+}
+
+// stopped is what stoppingWriter panics with.
+type stopped struct{ err error }
+
+// stoppingWriter panics when a write fails, to stop generators that don't
+// check every write and would otherwise keep going until they're done.
+type stoppingWriter struct{ w io.Writer }
+
+func (s stoppingWriter) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	if err != nil {
+		panic(stopped{err})
+	}
+	return n, nil
+}
@@ -0,0 +1,102 @@
+package gen_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/all"
+)
+
+// endless is the day of a generator writing size lines without checking
+// for errors, which takes forever at the largest sizes.
+const endless = 901
+
+// endlessRunning is how many of them are running.
+var endlessRunning atomic.Int32
+
+func init() {
+	gen.Register(endless, func(r *rand.Rand, w io.Writer, size int) error {
+		endlessRunning.Add(1)
+		defer endlessRunning.Add(-1)
+
+		bw := bufio.NewWriter(w)
+		for i := 0; i < size; i++ {
+			bw.WriteString("x\n")
+		}
+		return bw.Flush()
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	days := gen.Days()
+	if len(days) == 0 {
+		t.Fatal("got no generators, want one per day")
+	}
+
+	for _, day := range days {
+		day := day
+		t.Run(fmt.Sprintf("day %d", day), func(t *testing.T) {
+			t.Parallel()
+
+			for seed := int64(1); seed <= 2; seed++ {
+				for _, size := range []int{0, 1, 8} {
+					input, err := gen.Generate(day, seed, size)
+					if err != nil {
+						t.Fatal(err)
+					}
+					again, err := gen.Generate(day, seed, size)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !bytes.Equal(input, again) {
+						t.Fatalf("seed %d and size %d gave different inputs", seed, size)
+					}
+
+					for _, part := range []int{1, 2} {
+						solver, ok := registry.Lookup(day, part, registry.DefaultVariant)
+						if !ok {
+							continue
+						}
+						if _, err := solver.Solve(context.Background(), bytes.NewReader(input)); err != nil {
+							t.Errorf("solving part %d of the input from seed %d and size %d: %v\n%s", part, seed, size, err, input)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_unknownDay(t *testing.T) {
+	if _, err := gen.Generate(404, 1, 10); err == nil {
+		t.Errorf("got no error, want one for a day without a generator")
+	}
+}
+
+func TestReader_closeEarly(t *testing.T) {
+	r, err := gen.Reader(endless, 1, math.MaxInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadFull(r, make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+
+	for deadline := time.Now().Add(5 * time.Second); endlessRunning.Load() > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("generator still running after the reader was closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,295 @@
+// Package golden runs every registered solver of a day against the inputs
+// and expected answers listed in the day's testdata/answers.json.
+//
+// The answers file is a list of cases like:
+//
+//	[
+//	  {"input": "example.txt", "variants": ["default", "packets"], "answers": {"1": 13, "2": 140}},
+//	  {"accounts": true, "variants": ["packets"]}
+//	]
+//
+// Input paths are relative to the testdata directory. Variants default
+// to the default variant and a part without an answer is not checked.
+//
+// The day's inputs of every account in the repository's input store, see
+// package inputs, are checked as well against the account's answers, with
+// the default variant and the variants of any case marked accounts.
+package golden
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/inputs"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+const (
+	Dir      = "testdata"
+	Filename = "answers.json"
+)
+
+type Case struct {
+	Input string `json:"input,omitempty"`
+	// Accounts marks a case without an input or answers of its own, whose
+	// variants are checked on the account inputs of the input store too.
+	Accounts bool                   `json:"accounts,omitempty"`
+	Variants []string               `json:"variants,omitempty"`
+	Answers  map[int]answers.Answer `json:"answers,omitempty"`
+}
+
+func (c Case) VariantsOrDefault() []string {
+	if len(c.Variants) == 0 {
+		return []string{registry.DefaultVariant}
+	}
+	return c.Variants
+}
+
+// AccountVariants returns the variants to solve the account inputs of
+// the input store with, the default one and those of the accounts cases.
+func AccountVariants(cases []Case) []string {
+	variants := []string{registry.DefaultVariant}
+	for _, c := range cases {
+		if !c.Accounts {
+			continue
+		}
+		for _, v := range c.Variants {
+			if !contains(variants, v) {
+				variants = append(variants, v)
+			}
+		}
+	}
+	return variants
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Check is an input and the answer expected for it.
+type Check struct {
+	Input string
+	Want  answers.Answer
+}
+
+// ChecksFor returns the inputs with an answer for the key's part, out of
+// the cases checked with the key's variant.
+func ChecksFor(cases []Case, key registry.Key) []Check {
+	checks := make([]Check, 0, len(cases))
+	for _, c := range cases {
+		want, ok := c.Answers[key.Part]
+		if !ok {
+			continue
+		}
+		if contains(c.VariantsOrDefault(), key.Variant) {
+			checks = append(checks, Check{Input: c.Input, Want: want})
+		}
+	}
+	return checks
+}
+
+// Load reads the cases from the answers file in dir and resolves
+// their input paths relative to dir, except for the accounts cases.
+func Load(dir string) ([]Case, error) {
+	b, err := os.ReadFile(filepath.Join(dir, Filename))
+	if err != nil {
+		return nil, fmt.Errorf("reading answers: %w", err)
+	}
+
+	var cases []Case
+	if err := json.Unmarshal(b, &cases); err != nil {
+		return nil, fmt.Errorf("decoding answers: %w", err)
+	}
+
+	for i, c := range cases {
+		if c.Accounts {
+			if c.Input != "" || len(c.Answers) != 0 {
+				return nil, fmt.Errorf("case %d is for the accounts but has an input or answers", i)
+			}
+			continue
+		}
+		if c.Input == "" {
+			return nil, fmt.Errorf("case %d has no input", i)
+		}
+		cases[i].Input = filepath.Join(dir, c.Input)
+	}
+
+	return cases, nil
+}
+
+// Run creates a subtest per part, input and variant for the given day
+// using the answers file in the calling package's testdata directory,
+// and a subtest per part, account in the input store and account variant.
+func Run(t *testing.T, day int) {
+	t.Helper()
+
+	cases, err := Load(Dir)
+	if err != nil {
+		t.Fatalf("loading golden answers for day %d: %v", day, err)
+	}
+
+	store, err := repoStore()
+	if err != nil {
+		t.Fatalf("finding the input store: %v", err)
+	}
+	accountInputs, err := store.InputsOf(day)
+	if err != nil {
+		t.Fatalf("loading the inputs of day %d: %v", day, err)
+	}
+
+	for _, part := range partsOf(cases, accountInputs) {
+		part := part
+		t.Run(fmt.Sprintf("Part%d", part), func(t *testing.T) {
+			for _, c := range cases {
+				want, ok := c.Answers[part]
+				if !ok {
+					continue
+				}
+
+				for _, variant := range c.VariantsOrDefault() {
+					key := registry.Key{Day: day, Part: part, Variant: variant}
+					t.Run(nameOf(filepath.Base(c.Input), variant), func(t *testing.T) {
+						check(t, key, c.Input, want, func() (io.ReadCloser, error) {
+							return os.Open(c.Input)
+						})
+					})
+				}
+			}
+
+			for _, in := range accountInputs {
+				want, ok := in.Answers[part]
+				if !ok {
+					continue
+				}
+
+				for _, variant := range AccountVariants(cases) {
+					key := registry.Key{Day: day, Part: part, Variant: variant}
+					t.Run(nameOf(in.Path, variant), func(t *testing.T) {
+						check(t, key, in.Path, want, func() (io.ReadCloser, error) {
+							return store.Open(in.Account, in.Day)
+						})
+					})
+				}
+			}
+		})
+	}
+}
+
+// repoStore returns the input store of the repository, found by looking
+// for its go.mod from the working directory and up.
+func repoStore() (*inputs.Store, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return inputs.New(os.DirFS(filepath.Join(dir, inputs.Dir))), nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errors.New("no go.mod found")
+		}
+		dir = parent
+	}
+}
+
+// Seed adds the inputs of the answers file in dir to the fuzz corpus,
+// leaving out any real input living outside of dir as those are large
+// and not always checked in.
+func Seed(f *testing.F, dir string) {
+	f.Helper()
+
+	cases, err := Load(dir)
+	if err != nil {
+		f.Fatalf("loading golden answers: %v", err)
+	}
+
+	seen := make(map[string]struct{}, len(cases))
+	for _, c := range cases {
+		if _, ok := seen[c.Input]; ok || c.Accounts || filepath.Dir(c.Input) != filepath.Clean(dir) {
+			continue
+		}
+		seen[c.Input] = struct{}{}
+
+		b, err := os.ReadFile(c.Input)
+		if err != nil {
+			f.Fatalf("reading seed: %v", err)
+		}
+		f.Add(b)
+	}
+}
+
+func check(t *testing.T, key registry.Key, input string, want answers.Answer, open func() (io.ReadCloser, error)) {
+	t.Helper()
+
+	solver, ok := registry.Lookup(key.Day, key.Part, key.Variant)
+	if !ok {
+		t.Fatalf("no solver registered for %s", key)
+	}
+
+	f, err := open()
+	if err != nil {
+		t.Fatalf("opening %s: %v", input, err)
+	}
+	defer f.Close()
+
+	got, err := solver.Solve(context.Background(), f)
+	if err != nil {
+		t.Fatalf("solving part %d: %v", key.Part, err)
+	}
+
+	if !got.Equal(want) {
+		if got.Kind() == answers.KindRendered || want.Kind() == answers.KindRendered {
+			t.Errorf("got:\n%s\nwant:\n%s", got, want)
+		} else {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func partsOf(cases []Case, accountInputs []inputs.Input) []int {
+	seen := make(map[int]struct{})
+	parts := make([]int, 0, 2)
+	add := func(part int) {
+		if _, ok := seen[part]; !ok {
+			seen[part] = struct{}{}
+			parts = append(parts, part)
+		}
+	}
+	for _, c := range cases {
+		for part := range c.Answers {
+			add(part)
+		}
+	}
+	for _, in := range accountInputs {
+		for part := range in.Answers {
+			add(part)
+		}
+	}
+	sort.Ints(parts)
+	return parts
+}
+
+func nameOf(input, variant string) string {
+	name := input
+	if variant != registry.DefaultVariant {
+		name += "/" + variant
+	}
+	return name
+}
@@ -77,3 +77,22 @@ func (b Bounds) IsInside(loc Loc) bool {
 	return minX <= x && x <= maxX &&
 		minY <= y && y <= maxY
 }
+
+// Locs returns every location within the bounds, row by row.
+func (b Bounds) Locs() []Loc {
+	if b.isEmpty() {
+		return nil
+	}
+
+	locs := make([]Loc, 0, (b.Width()+1)*(b.Height()+1))
+	for y := b.minY; y <= b.maxY; y++ {
+		for x := b.minX; x <= b.maxX; x++ {
+			locs = append(locs, Loc{x, y})
+		}
+	}
+	return locs
+}
+
+func (b Bounds) isEmpty() bool {
+	return b.minX > b.maxX || b.minY > b.maxY
+}
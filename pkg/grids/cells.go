@@ -0,0 +1,20 @@
+package grids
+
+// Cells is a grid of values regardless of how they're stored, so that
+// puzzles can pick the sparse Grid or the slice-backed Dense without
+// rewriting the logic walking it.
+type Cells[T comparable] interface {
+	// At returns the value at loc and whether it's been set, which is the
+	// zero value and false for the cells that haven't, in bounds or not.
+	At(loc Loc) (T, bool)
+	// Set sets the value at loc, growing the bounds to include it.
+	Set(loc Loc, value T)
+	Bounds() Bounds
+	// Render draws the cells within the bounds, a line per row.
+	Render() string
+}
+
+var (
+	_ Cells[int] = (*Grid[int])(nil)
+	_ Cells[int] = (*Dense[int])(nil)
+)
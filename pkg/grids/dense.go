@@ -0,0 +1,153 @@
+package grids
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dense is a grid storing every cell within its bounds in a slice, which
+// is faster and smaller than Grid when most of the cells are set.
+type Dense[T comparable] struct {
+	bounds Bounds
+	width  int
+
+	// cells holds fill for the cells that aren't set, as told by set.
+	cells []T
+	set   []bool
+	fill  T
+}
+
+// NewDense returns a grid covering bounds without any cell set, which are
+// rendered as fill.
+func NewDense[T comparable](bounds Bounds, fill T) *Dense[T] {
+	d := &Dense[T]{bounds: bounds, fill: fill}
+	if bounds.isEmpty() {
+		d.bounds = emptyBounds
+		return d
+	}
+
+	d.width = bounds.Width() + 1
+	d.cells = make([]T, d.width*(bounds.Height()+1))
+	d.set = make([]bool, len(d.cells))
+	var zero T
+	if fill != zero {
+		for i := range d.cells {
+			d.cells[i] = fill
+		}
+	}
+	return d
+}
+
+// DenseOf returns a grid of rows with its top left corner at {0, 0},
+// indexed rows[y][x], with every cell set. It panics if the rows aren't all the same length.
+func DenseOf[T comparable](rows [][]T) *Dense[T] {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		var fill T
+		return NewDense(emptyBounds, fill)
+	}
+
+	width := len(rows[0])
+	d := &Dense[T]{
+		bounds: NewBounds(0, width-1, 0, len(rows)-1),
+		width:  width,
+		cells:  make([]T, 0, width*len(rows)),
+	}
+	for y, row := range rows {
+		if len(row) != width {
+			panic(fmt.Sprintf("grids: row %d is %d wide, want %d", y, len(row), width))
+		}
+		d.cells = append(d.cells, row...)
+	}
+	d.set = make([]bool, len(d.cells))
+	for i := range d.set {
+		d.set[i] = true
+	}
+	return d
+}
+
+func (d *Dense[T]) Copy() *Dense[T] {
+	cp := *d
+	cp.cells = append([]T(nil), d.cells...)
+	cp.set = append([]bool(nil), d.set...)
+	return &cp
+}
+
+func (d *Dense[T]) index(loc Loc) int {
+	x, y := loc.XY()
+	return (y-d.bounds.minY)*d.width + (x - d.bounds.minX)
+}
+
+// At returns the value at loc and whether it's been set, like Grid.At.
+func (d *Dense[T]) At(at Loc) (T, bool) {
+	if !d.bounds.IsInside(at) || !d.set[d.index(at)] {
+		var zero T
+		return zero, false
+	}
+	return d.cells[d.index(at)], true
+}
+
+// Set sets the value at loc, growing the grid to include it if it's out
+// of bounds.
+func (d *Dense[T]) Set(loc Loc, value T) {
+	if !d.bounds.IsInside(loc) {
+		d.grow(d.bounds.Extend(loc))
+	}
+	i := d.index(loc)
+	d.cells[i], d.set[i] = value, true
+}
+
+func (d *Dense[T]) grow(bounds Bounds) {
+	grown := NewDense(bounds, d.fill)
+	for y := d.bounds.minY; y <= d.bounds.maxY; y++ {
+		from := d.index(Loc{d.bounds.minX, y})
+		to := grown.index(Loc{d.bounds.minX, y})
+		copy(grown.cells[to:to+d.width], d.cells[from:from+d.width])
+		copy(grown.set[to:to+d.width], d.set[from:from+d.width])
+	}
+	*d = *grown
+}
+
+func (d *Dense[T]) Bounds() Bounds {
+	return d.bounds
+}
+
+func (d *Dense[T]) InBounds(loc Loc) bool {
+	return d.bounds.IsInside(loc)
+}
+
+// Neighbors4 is like Loc.Neighbors4, leaving out the locations outside
+// the grid.
+func (d *Dense[T]) Neighbors4(loc Loc) []Loc {
+	return within(d.bounds, loc.Neighbors4())
+}
+
+// Neighbors8 is like Loc.Neighbors8, leaving out the locations outside
+// the grid.
+func (d *Dense[T]) Neighbors8(loc Loc) []Loc {
+	return within(d.bounds, loc.Neighbors8())
+}
+
+func (d *Dense[T]) Count(value T) int {
+	counter := 0
+	for i, v := range d.cells {
+		if d.set[i] && v == value {
+			counter++
+		}
+	}
+	return counter
+}
+
+func (d *Dense[T]) Render() string {
+	sb := &strings.Builder{}
+	for i, v := range d.cells {
+		sb.WriteString(fmt.Sprint(v))
+		if (i+1)%d.width == 0 {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+func (d *Dense[T]) String() string {
+	return d.Render()
+}
@@ -0,0 +1,57 @@
+package grids_test
+
+import (
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+func TestCells(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells grids.Cells[string]
+	}{
+		{"sparse", grids.NewGrid(".")},
+		{"dense", grids.NewDense(grids.NewBounds(0, 0, 0, 0), ".")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cells.Set(grids.Loc{0, 0}, "#")
+			tt.cells.Set(grids.Loc{2, 1}, "#")
+			tt.cells.Set(grids.Loc{-1, -1}, "#")
+
+			if got, want := tt.cells.Render(), "#...\n.#..\n...#\n"; got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+			if got, want := tt.cells.Bounds(), grids.NewBounds(-1, 2, -1, 1); got != want {
+				t.Errorf("got bounds %v, want %v", got, want)
+			}
+			if v, ok := tt.cells.At(grids.Loc{2, 1}); !ok || v != "#" {
+				t.Errorf("got %q, %t at {2, 1}, want \"#\", true", v, ok)
+			}
+			if _, ok := tt.cells.At(grids.Loc{3, 1}); ok {
+				t.Errorf("got a value out of bounds")
+			}
+			if v, ok := tt.cells.At(grids.Loc{1, 0}); ok || v != "" {
+				t.Errorf("got %q, %t at {1, 0} which isn't set, want \"\", false", v, ok)
+			}
+		})
+	}
+}
+
+func TestDenseOf(t *testing.T) {
+	d := grids.DenseOf([][]int{{1, 2, 3}, {4, 5, 6}})
+
+	if got, want := d.Bounds(), grids.NewBounds(0, 2, 0, 1); got != want {
+		t.Errorf("got bounds %v, want %v", got, want)
+	}
+	if v, _ := d.At(grids.Loc{2, 0}); v != 3 {
+		t.Errorf("got %d at {2, 0}, want 3", v)
+	}
+	if v, _ := d.At(grids.Loc{0, 1}); v != 4 {
+		t.Errorf("got %d at {0, 1}, want 4", v)
+	}
+	if got, want := d.Render(), "123\n456\n"; got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
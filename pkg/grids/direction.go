@@ -0,0 +1,76 @@
+package grids
+
+import "fmt"
+
+// Direction is one of the four directions on a grid, with y growing
+// downwards like when the grid is rendered.
+type Direction int
+
+// The directions are in clockwise order, so that turning is a step
+// forwards or backwards.
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+// Directions are the four directions in clockwise order, starting up.
+var Directions = [4]Direction{Up, Right, Down, Left}
+
+var directionNames = [4]string{"up", "right", "down", "left"}
+
+var vectors = [4]Loc{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// DirectionOf returns the direction r stands for, out of U, R, D and L,
+// the arrows ^, >, v and <, and the compass points N, E, S and W.
+func DirectionOf(r rune) (Direction, bool) {
+	switch r {
+	case 'U', '^', 'N':
+		return Up, true
+	case 'R', '>', 'E':
+		return Right, true
+	case 'D', 'v', 'S':
+		return Down, true
+	case 'L', '<', 'W':
+		return Left, true
+	default:
+		return 0, false
+	}
+}
+
+// ParseDirection is like DirectionOf, for a string of a single rune.
+func ParseDirection(s string) (Direction, error) {
+	r := []rune(s)
+	if len(r) == 1 {
+		if d, ok := DirectionOf(r[0]); ok {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown direction %q, want one of U/R/D/L, ^/>/v/< or N/E/S/W", s)
+}
+
+func (d Direction) TurnRight() Direction {
+	return (d + 1) % 4
+}
+
+func (d Direction) TurnLeft() Direction {
+	return (d + 3) % 4
+}
+
+func (d Direction) TurnAround() Direction {
+	return (d + 2) % 4
+}
+
+// Vector returns the unit vector of the direction, the step to take to
+// move one square in it.
+func (d Direction) Vector() Loc {
+	return vectors[d]
+}
+
+func (d Direction) String() string {
+	if d < Up || Left < d {
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+	return directionNames[d]
+}
@@ -0,0 +1,72 @@
+package grids_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+func TestParseDirection(t *testing.T) {
+	tests := map[grids.Direction][]string{
+		grids.Up:    {"U", "^", "N"},
+		grids.Right: {"R", ">", "E"},
+		grids.Down:  {"D", "v", "S"},
+		grids.Left:  {"L", "<", "W"},
+	}
+	for want, inputs := range tests {
+		for _, s := range inputs {
+			got, err := grids.ParseDirection(s)
+			if err != nil || got != want {
+				t.Errorf("got %v, %v for %q, want %v", got, err, s, want)
+			}
+		}
+	}
+
+	for _, s := range []string{"", "X", "UU", "u"} {
+		if _, err := grids.ParseDirection(s); err == nil {
+			t.Errorf("got no error for %q", s)
+		}
+	}
+}
+
+func TestDirection_turn(t *testing.T) {
+	for _, d := range grids.Directions {
+		if got := d.TurnRight().TurnLeft(); got != d {
+			t.Errorf("got %v turning %v right and left, want it back", got, d)
+		}
+		if got := d.TurnAround().Vector(); got != (grids.Loc{-d.Vector()[0], -d.Vector()[1]}) {
+			t.Errorf("got %v turning %v around, want the opposite of %v", got, d, d.Vector())
+		}
+	}
+	if got := grids.Up.TurnRight(); got != grids.Right {
+		t.Errorf("got %v turning up right, want right", got)
+	}
+	if got := grids.Up.TurnLeft(); got != grids.Left {
+		t.Errorf("got %v turning up left, want left", got)
+	}
+	if got, want := grids.Up.Vector(), (grids.Loc{0, -1}); got != want {
+		t.Errorf("got %v going up, want %v", got, want)
+	}
+}
+
+func TestLoc_neighbors(t *testing.T) {
+	l := grids.Loc{1, 1}
+
+	want4 := []grids.Loc{{1, 0}, {2, 1}, {1, 2}, {0, 1}}
+	if got := l.Neighbors4(); !reflect.DeepEqual(got, want4) {
+		t.Errorf("got %v, want %v", got, want4)
+	}
+	want8 := []grids.Loc{{1, 0}, {2, 0}, {2, 1}, {2, 2}, {1, 2}, {0, 2}, {0, 1}, {0, 0}}
+	if got := l.Neighbors8(); !reflect.DeepEqual(got, want8) {
+		t.Errorf("got %v, want %v", got, want8)
+	}
+
+	d := grids.DenseOf([][]int{{1, 2}, {3, 4}})
+	if got, want := d.Neighbors4(grids.Loc{0, 0}), []grids.Loc{{1, 0}, {0, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v in bounds, want %v", got, want)
+	}
+	if got, want := d.Neighbors8(grids.Loc{0, 0}), []grids.Loc{{1, 0}, {1, 1}, {0, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v in bounds, want %v", got, want)
+	}
+}
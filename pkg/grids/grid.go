@@ -35,12 +35,19 @@ func (g *Grid[T]) Copy() *Grid[T] {
 	}
 }
 
+// At returns the value at loc and whether it's been set.
 func (g *Grid[T]) At(at Loc) (T, bool) {
 	value, ok := g.values[at]
 	return value, ok
 }
 
 func (g *Grid[T]) String() string {
+	return g.Render()
+}
+
+// Render draws the grid within its bounds, using the empty value for the
+// cells that aren't set.
+func (g *Grid[T]) Render() string {
 	return g.RenderArea(g.bounds)
 }
 
@@ -98,8 +105,18 @@ func (g *Grid[T]) Bounds() Bounds {
 	return g.bounds
 }
 
-
-
 func (g *Grid[T]) InBounds(loc Loc) bool {
 	return g.bounds.IsInside(loc)
 }
+
+// Neighbors4 is like Loc.Neighbors4, leaving out the locations outside
+// the grid's bounds.
+func (g *Grid[T]) Neighbors4(loc Loc) []Loc {
+	return within(g.bounds, loc.Neighbors4())
+}
+
+// Neighbors8 is like Loc.Neighbors8, leaving out the locations outside
+// the grid's bounds.
+func (g *Grid[T]) Neighbors8(loc Loc) []Loc {
+	return within(g.bounds, loc.Neighbors8())
+}
@@ -15,3 +15,35 @@ func (l Loc) String() string {
 func (l Loc) XY() (x, y int) {
 	return l[0], l[1]
 }
+
+// Neighbors4 returns the locations next to l, up, right, down and left of
+// it.
+func (l Loc) Neighbors4() []Loc {
+	neighbors := make([]Loc, 0, 4)
+	for _, d := range Directions {
+		neighbors = append(neighbors, l.Add(d.Vector()))
+	}
+	return neighbors
+}
+
+// Neighbors8 returns the locations around l including the diagonal ones,
+// clockwise starting up.
+func (l Loc) Neighbors8() []Loc {
+	neighbors := make([]Loc, 0, 8)
+	for _, d := range Directions {
+		step := d.Vector()
+		neighbors = append(neighbors, l.Add(step), l.Add(step).Add(d.TurnRight().Vector()))
+	}
+	return neighbors
+}
+
+// within returns the locations of locs inside bounds, reusing locs.
+func within(bounds Bounds, locs []Loc) []Loc {
+	inside := locs[:0]
+	for _, l := range locs {
+		if bounds.IsInside(l) {
+			inside = append(inside, l)
+		}
+	}
+	return inside
+}
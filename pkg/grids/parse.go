@@ -0,0 +1,67 @@
+package grids
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+)
+
+// Parse reads a grid from the day's input in reader, a line per row with
+// the first one at y 0, skipping blank lines. Every rune is mapped to its
+// value by value, which returns false for runes that aren't allowed.
+//
+// The location of each of the markers found is returned as well, which
+// are mapped like any other rune and may each appear at most once.
+//
+// Unknown runes, ragged rows and repeated markers are reported as a
+// *parse.Error.
+func Parse[T comparable](day int, reader io.Reader, value func(r rune) (T, bool), markers ...rune) (*Dense[T], map[rune]Loc, error) {
+	found := make(map[rune]Loc, len(markers))
+	isMarker := make(map[rune]bool, len(markers))
+	for _, m := range markers {
+		isMarker[m] = true
+	}
+
+	rows := make([][]T, 0)
+	scanner := parse.NewScanner(day, reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		row := make([]T, 0, len(line))
+		for i, r := range []rune(line) {
+			column := i + 1
+			if len(rows) > 0 && i == len(rows[0]) {
+				return nil, nil, scanner.WrapColumn(column, string(r), fmt.Errorf("row is wider than the first one, which is %d wide", len(rows[0])))
+			}
+
+			v, ok := value(r)
+			if !ok {
+				return nil, nil, scanner.WrapColumn(column, string(r), fmt.Errorf("unexpected %q", r))
+			}
+
+			if isMarker[r] {
+				at := Loc{i, len(rows)}
+				if first, ok := found[r]; ok {
+					return nil, nil, scanner.WrapColumn(column, string(r), fmt.Errorf("second %q, first one is at %s", r, first))
+				}
+				found[r] = at
+			}
+
+			row = append(row, v)
+		}
+
+		if len(rows) > 0 && len(row) < len(rows[0]) {
+			return nil, nil, scanner.Errorf("", "row is %d wide, want %d like the first one", len(row), len(rows[0]))
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return DenseOf(rows), found, nil
+}
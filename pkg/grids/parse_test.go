@@ -0,0 +1,75 @@
+package grids_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+)
+
+func wall(r rune) (bool, bool) {
+	switch r {
+	case '#':
+		return true, true
+	case '.', 'S', 'E':
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func TestParse(t *testing.T) {
+	grid, markers, err := grids.Parse(1, strings.NewReader("#S.\n\n..#\n.E.\n"), wall, 'S', 'E', 'X')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := grid.Bounds(), grids.NewBounds(0, 2, 0, 2); got != want {
+		t.Errorf("got bounds %v, want %v", got, want)
+	}
+	if got, want := grid.Count(true), 2; got != want {
+		t.Errorf("got %d walls, want %d", got, want)
+	}
+	if v, _ := grid.At(grids.Loc{2, 1}); !v {
+		t.Errorf("got no wall at {2, 1}")
+	}
+
+	want := map[rune]grids.Loc{'S': {1, 0}, 'E': {1, 2}}
+	if len(markers) != len(want) {
+		t.Errorf("got markers %v, want %v", markers, want)
+	}
+	for r, loc := range want {
+		if markers[r] != loc {
+			t.Errorf("got %q at %v, want %v", r, markers[r], loc)
+		}
+	}
+}
+
+func TestParse_malformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		line   int
+		column int
+	}{
+		{"unknown rune", "#S.\n.?.\n", 2, 2},
+		{"short row", "#S.\n..\n", 2, 0},
+		{"long row", "#S.\n\n....\n", 3, 4},
+		{"second marker", "#S.\n.S.\n", 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := grids.Parse(1, strings.NewReader(tt.input), wall, 'S')
+
+			var perr *parse.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("got error %v, want a parse error", err)
+			}
+			if perr.Line != tt.line || perr.Column != tt.column {
+				t.Errorf("got error at line %d, column %d, want line %d, column %d", perr.Line, perr.Column, tt.line, tt.column)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package grids
+
+// Search is the result of a breadth first search on a grid.
+type Search struct {
+	// Dist is the number of steps to every location reached from the
+	// nearest source, which are at 0.
+	Dist map[Loc]int
+	// Prev is the location every location reached was first reached
+	// from. The sources have none.
+	Prev map[Loc]Loc
+}
+
+// Reached reports whether the search reached to.
+func (s Search) Reached(to Loc) bool {
+	_, ok := s.Dist[to]
+	return ok
+}
+
+// Path returns the shortest path from the nearest source to to, both
+// included, and whether to was reached at all.
+func (s Search) Path(to Loc) ([]Loc, bool) {
+	dist, ok := s.Dist[to]
+	if !ok {
+		return nil, false
+	}
+
+	path := make([]Loc, dist+1)
+	for i, at := dist, to; i >= 0; i-- {
+		path[i] = at
+		at = s.Prev[at]
+	}
+	return path, true
+}
+
+// BFS searches breadth first from all sources at once, stepping up,
+// right, down and left to any location within bounds that passable
+// allows stepping to from the current one.
+func BFS(bounds Bounds, sources []Loc, passable func(from, to Loc) bool) Search {
+	s := Search{
+		Dist: make(map[Loc]int),
+		Prev: make(map[Loc]Loc),
+	}
+
+	queue := make([]Loc, 0, len(sources))
+	for _, src := range sources {
+		if _, ok := s.Dist[src]; ok || !bounds.IsInside(src) {
+			continue
+		}
+		s.Dist[src] = 0
+		queue = append(queue, src)
+	}
+
+	for len(queue) > 0 {
+		at := queue[0]
+		queue = queue[1:]
+
+		for _, next := range at.Neighbors4() {
+			if _, seen := s.Dist[next]; seen || !bounds.IsInside(next) || !passable(at, next) {
+				continue
+			}
+			s.Dist[next] = s.Dist[at] + 1
+			s.Prev[next] = at
+			queue = append(queue, next)
+		}
+	}
+
+	return s
+}
+
+// FloodFill sets the region of locations around from with the same value
+// as it, up, right, down and left of each other, to value, and returns
+// them. Nothing is filled if from has no value.
+func FloodFill[T comparable](g Cells[T], from Loc, value T) []Loc {
+	old, ok := g.At(from)
+	if !ok {
+		return nil
+	}
+
+	region := BFS(g.Bounds(), []Loc{from}, func(_, to Loc) bool {
+		v, ok := g.At(to)
+		return ok && v == old
+	})
+
+	filled := make([]Loc, 0, len(region.Dist))
+	for _, loc := range g.Bounds().Locs() {
+		if region.Reached(loc) {
+			g.Set(loc, value)
+			filled = append(filled, loc)
+		}
+	}
+	return filled
+}
+
+// Components labels the connected regions of g, where two locations next
+// to each other are connected if connected says so either way round.
+// Every location with a value gets the label of its region, numbered
+// from 0 in the order they're first found row by row, while the ones
+// without a value are left unset and rendered as -1. The number of
+// regions is returned as well.
+func Components[T comparable](g Cells[T], connected func(a, b Loc) bool) (*Dense[int], int) {
+	labels := NewDense(g.Bounds(), -1)
+	hasValue := func(loc Loc) bool {
+		_, ok := g.At(loc)
+		return ok
+	}
+
+	n := 0
+	for _, loc := range labels.Bounds().Locs() {
+		if _, labelled := labels.At(loc); labelled || !hasValue(loc) {
+			continue
+		}
+
+		region := BFS(labels.Bounds(), []Loc{loc}, func(from, to Loc) bool {
+			return hasValue(to) && (connected(from, to) || connected(to, from))
+		})
+		for at := range region.Dist {
+			labels.Set(at, n)
+		}
+		n++
+	}
+
+	return labels, n
+}
@@ -0,0 +1,104 @@
+package grids_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+func parseMaze(t *testing.T, maze string) (*grids.Dense[string], map[rune]grids.Loc) {
+	t.Helper()
+
+	grid, markers, err := grids.Parse(1, strings.NewReader(maze), func(r rune) (string, bool) {
+		return string(r), true
+	}, 'S', 'E')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grid, markers
+}
+
+func TestBFS(t *testing.T) {
+	grid, markers := parseMaze(t, strings.Join([]string{
+		"S.#...",
+		"#.#.#.",
+		"..#.#E",
+		".##.#.",
+		"......",
+	}, "\n"))
+	open := func(_, to grids.Loc) bool {
+		v, _ := grid.At(to)
+		return v != "#"
+	}
+
+	search := grids.BFS(grid.Bounds(), []grids.Loc{markers['S']}, open)
+	if got, want := search.Dist[markers['E']], 13; got != want {
+		t.Errorf("got distance %d to E, want %d", got, want)
+	}
+	path, ok := search.Path(markers['E'])
+	if !ok {
+		t.Fatal("got no path to E")
+	}
+	if len(path) != 14 || path[0] != markers['S'] || path[len(path)-1] != markers['E'] {
+		t.Errorf("got path %v, want 13 steps from S to E", path)
+	}
+	for i := 1; i < len(path); i++ {
+		if d := path[i].Add(grids.Loc{-path[i-1][0], -path[i-1][1]}); d[0]*d[0]+d[1]*d[1] != 1 {
+			t.Fatalf("got a jump from %v to %v in path %v", path[i-1], path[i], path)
+		}
+	}
+
+	// From both ends at once, the middle is reached from the closest.
+	search = grids.BFS(grid.Bounds(), []grids.Loc{markers['S'], markers['E']}, open)
+	if got, want := search.Dist[grids.Loc{3, 4}], 4; got != want {
+		t.Errorf("got distance %d to the bottom, want %d", got, want)
+	}
+	if path, _ := search.Path(grids.Loc{3, 0}); path[0] != markers['E'] {
+		t.Errorf("got path %v, want it from E", path)
+	}
+
+	if search.Reached(grids.Loc{2, 0}) {
+		t.Errorf("got a wall reached")
+	}
+	if _, ok := search.Path(grids.Loc{9, 9}); ok {
+		t.Errorf("got a path out of bounds")
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+	grid, _ := parseMaze(t, strings.Join([]string{
+		"..#.",
+		"###.",
+		"..#.",
+	}, "\n"))
+
+	filled := grids.FloodFill[string](grid, grids.Loc{3, 2}, "o")
+	if want := []grids.Loc{{3, 0}, {3, 1}, {3, 2}}; !reflect.DeepEqual(filled, want) {
+		t.Errorf("got %v filled, want %v", filled, want)
+	}
+	if got, want := grid.Render(), "..#o\n###o\n..#o\n"; got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	grid := grids.NewGrid(".")
+	for _, loc := range []grids.Loc{{0, 0}, {1, 0}, {3, 0}, {3, 1}, {0, 2}, {1, 2}, {2, 2}, {3, 2}} {
+		grid.Set(loc, "#")
+	}
+	grid.Set(grids.Loc{1, 1}, "o")
+
+	labels, n := grids.Components[string](grid, func(a, b grids.Loc) bool {
+		va, _ := grid.At(a)
+		vb, _ := grid.At(b)
+		return va == vb
+	})
+	if n != 3 {
+		t.Errorf("got %d components, want 3", n)
+	}
+	if got, want := labels.Render(), "00-11\n-12-11\n1111\n"; got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
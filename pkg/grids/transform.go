@@ -0,0 +1,191 @@
+package grids
+
+import "fmt"
+
+// transform moves the locations within bounds, returning the bounds they
+// end up within and where each location goes. The top left corner of the
+// bounds stays put.
+type transform func(bounds Bounds) (Bounds, func(Loc) Loc)
+
+// rotation returns the transform turning a grid clockwise by degrees,
+// which must be a multiple of 90.
+func rotation(degrees int) transform {
+	if degrees%90 != 0 {
+		panic(fmt.Sprintf("grids: can't rotate by %d degrees, only by multiples of 90", degrees))
+	}
+
+	return func(b Bounds) (Bounds, func(Loc) Loc) {
+		w, h := b.Width(), b.Height()
+		corner := Loc{b.minX, b.minY}
+
+		var turned Bounds
+		var move func(dx, dy int) Loc
+		switch (degrees/90%4 + 4) % 4 {
+		case 0:
+			turned, move = b, func(dx, dy int) Loc { return Loc{dx, dy} }
+		case 1:
+			turned, move = b.resized(h, w), func(dx, dy int) Loc { return Loc{h - dy, dx} }
+		case 2:
+			turned, move = b, func(dx, dy int) Loc { return Loc{w - dx, h - dy} }
+		default:
+			turned, move = b.resized(h, w), func(dx, dy int) Loc { return Loc{dy, w - dx} }
+		}
+
+		return turned, func(l Loc) Loc {
+			return move(l[0]-corner[0], l[1]-corner[1]).Add(corner)
+		}
+	}
+}
+
+// flip is the transform mirroring a grid, left to right if horizontal
+// and top to bottom if not.
+func flip(horizontal bool) transform {
+	return func(b Bounds) (Bounds, func(Loc) Loc) {
+		return b, func(l Loc) Loc {
+			if horizontal {
+				return Loc{b.minX + b.maxX - l[0], l[1]}
+			}
+			return Loc{l[0], b.minY + b.maxY - l[1]}
+		}
+	}
+}
+
+// transpose is the transform mirroring a grid along the diagonal from its
+// top left corner.
+func transpose(b Bounds) (Bounds, func(Loc) Loc) {
+	return b.resized(b.Height(), b.Width()), func(l Loc) Loc {
+		return Loc{b.minX + l[1] - b.minY, b.minY + l[0] - b.minX}
+	}
+}
+
+// resized returns bounds with the same top left corner as b, and the
+// given width and height.
+func (b Bounds) resized(width, height int) Bounds {
+	return NewBounds(b.minX, b.minX+width, b.minY, b.minY+height)
+}
+
+// Rotate returns a copy of the grid turned clockwise by degrees. The
+// bounds of the copy keep their top left corner, swapping width and
+// height when turned a quarter. It panics unless degrees is a multiple
+// of 90.
+func (g *Grid[T]) Rotate(degrees int) *Grid[T] {
+	return g.transform(rotation(degrees))
+}
+
+// FlipHorizontal returns a copy of the grid mirrored left to right.
+func (g *Grid[T]) FlipHorizontal() *Grid[T] {
+	return g.transform(flip(true))
+}
+
+// FlipVertical returns a copy of the grid mirrored top to bottom.
+func (g *Grid[T]) FlipVertical() *Grid[T] {
+	return g.transform(flip(false))
+}
+
+// Transpose returns a copy of the grid with rows and columns swapped.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.transform(transpose)
+}
+
+func (g *Grid[T]) transform(t transform) *Grid[T] {
+	if g.bounds.isEmpty() {
+		return g.Copy()
+	}
+
+	bounds, move := t(g.bounds)
+	moved := &Grid[T]{
+		bounds:   bounds,
+		values:   make(map[Loc]T, len(g.values)),
+		emptyVal: g.emptyVal,
+	}
+	for l, v := range g.values {
+		moved.values[move(l)] = v
+	}
+	return moved
+}
+
+// Crop returns a copy of the part of the grid within bounds, which are
+// the bounds of the copy.
+func (g *Grid[T]) Crop(bounds Bounds) *Grid[T] {
+	cropped := &Grid[T]{
+		bounds:   bounds,
+		values:   make(map[Loc]T),
+		emptyVal: g.emptyVal,
+	}
+	for l, v := range g.values {
+		if bounds.IsInside(l) {
+			cropped.values[l] = v
+		}
+	}
+	return cropped
+}
+
+// Paste returns a copy of the grid with the values of src set on top of
+// it, moved by offset. The cells that aren't set in src, see Cells.At,
+// are left as they are.
+func (g *Grid[T]) Paste(src Cells[T], offset Loc) *Grid[T] {
+	pasted := g.Copy()
+	for _, l := range src.Bounds().Locs() {
+		if v, ok := src.At(l); ok {
+			pasted.Set(l.Add(offset), v)
+		}
+	}
+	return pasted
+}
+
+// Rotate is like Grid.Rotate.
+func (d *Dense[T]) Rotate(degrees int) *Dense[T] {
+	return d.transform(rotation(degrees))
+}
+
+// FlipHorizontal is like Grid.FlipHorizontal.
+func (d *Dense[T]) FlipHorizontal() *Dense[T] {
+	return d.transform(flip(true))
+}
+
+// FlipVertical is like Grid.FlipVertical.
+func (d *Dense[T]) FlipVertical() *Dense[T] {
+	return d.transform(flip(false))
+}
+
+// Transpose is like Grid.Transpose.
+func (d *Dense[T]) Transpose() *Dense[T] {
+	return d.transform(transpose)
+}
+
+func (d *Dense[T]) transform(t transform) *Dense[T] {
+	if d.bounds.isEmpty() {
+		return d.Copy()
+	}
+
+	bounds, move := t(d.bounds)
+	moved := NewDense(bounds, d.fill)
+	for i, l := range d.bounds.Locs() {
+		j := moved.index(move(l))
+		moved.cells[j], moved.set[j] = d.cells[i], d.set[i]
+	}
+	return moved
+}
+
+// Crop returns a copy of the part of the grid within bounds, which are
+// the bounds of the copy, filling any part outside of the grid.
+func (d *Dense[T]) Crop(bounds Bounds) *Dense[T] {
+	cropped := NewDense(bounds, d.fill)
+	for _, l := range bounds.Locs() {
+		if v, ok := d.At(l); ok {
+			cropped.Set(l, v)
+		}
+	}
+	return cropped
+}
+
+// Paste is like Grid.Paste, growing the copy to fit src if needed.
+func (d *Dense[T]) Paste(src Cells[T], offset Loc) *Dense[T] {
+	pasted := d.Copy()
+	for _, l := range src.Bounds().Locs() {
+		if v, ok := src.At(l); ok {
+			pasted.Set(l.Add(offset), v)
+		}
+	}
+	return pasted
+}
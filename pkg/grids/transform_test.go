@@ -0,0 +1,140 @@
+package grids_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+// tile returns a grid of 3 by 2 with its top left corner at {10, 20},
+// where the dots aren't set.
+func tile() *grids.Grid[string] {
+	g := grids.NewGrid(".")
+	for y, row := range []string{"ab.", "d#f"} {
+		for x, v := range strings.Split(row, "") {
+			if v != "." {
+				g.Set(grids.Loc{10 + x, 20 + y}, v)
+			}
+		}
+	}
+	return g
+}
+
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name   string
+		sparse func(g *grids.Grid[string]) *grids.Grid[string]
+		dense  func(d *grids.Dense[string]) *grids.Dense[string]
+		bounds grids.Bounds
+		want   string
+	}{
+		{
+			"rotate 90",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Rotate(90) },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Rotate(90) },
+			grids.NewBounds(10, 11, 20, 22), "da\n#b\nf.\n",
+		},
+		{
+			"rotate 180",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Rotate(180) },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Rotate(180) },
+			grids.NewBounds(10, 12, 20, 21), "f#d\n.ba\n",
+		},
+		{
+			"rotate 270",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Rotate(270) },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Rotate(270) },
+			grids.NewBounds(10, 11, 20, 22), ".f\nb#\nad\n",
+		},
+		{
+			"rotate -90",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Rotate(-90) },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Rotate(-90) },
+			grids.NewBounds(10, 11, 20, 22), ".f\nb#\nad\n",
+		},
+		{
+			"rotate 360",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Rotate(360) },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Rotate(360) },
+			grids.NewBounds(10, 12, 20, 21), "ab.\nd#f\n",
+		},
+		{
+			"flip horizontal",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.FlipHorizontal() },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.FlipHorizontal() },
+			grids.NewBounds(10, 12, 20, 21), ".ba\nf#d\n",
+		},
+		{
+			"flip vertical",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.FlipVertical() },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.FlipVertical() },
+			grids.NewBounds(10, 12, 20, 21), "d#f\nab.\n",
+		},
+		{
+			"transpose",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Transpose() },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Transpose() },
+			grids.NewBounds(10, 11, 20, 22), "ad\nb#\n.f\n",
+		},
+		{
+			"crop",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Crop(grids.NewBounds(11, 13, 21, 21)) },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Crop(grids.NewBounds(11, 13, 21, 21)) },
+			grids.NewBounds(11, 13, 21, 21), "#f.\n",
+		},
+		{
+			"paste",
+			func(g *grids.Grid[string]) *grids.Grid[string] { return g.Paste(tile(), grids.Loc{2, 1}) },
+			func(d *grids.Dense[string]) *grids.Dense[string] { return d.Paste(tile(), grids.Loc{2, 1}) },
+			grids.NewBounds(10, 14, 20, 22), "ab...\nd#ab.\n..d#f\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tile()
+			sparse := tt.sparse(original)
+			if got := sparse.Bounds(); got != tt.bounds {
+				t.Errorf("got bounds %v, want %v", got, tt.bounds)
+			}
+			if got := sparse.Render(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if got, want := original.Render(), "ab.\nd#f\n"; got != want {
+				t.Errorf("got the original changed to:\n%s", got)
+			}
+
+			dense := tt.dense(grids.NewDense(original.Bounds(), ".").Paste(original, grids.Loc{}))
+			if got := dense.Bounds(); got != tt.bounds {
+				t.Errorf("got dense bounds %v, want %v", got, tt.bounds)
+			}
+			if got := dense.Render(); got != tt.want {
+				t.Errorf("got dense:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDense_paste(t *testing.T) {
+	dst := grids.DenseOf([][]string{{"A", "B", "x"}})
+
+	// Only the set cells of a dense source are pasted, not its fill.
+	src := grids.NewDense(grids.NewBounds(0, 1, 0, 0), ".")
+	src.Set(grids.Loc{1, 0}, "#")
+	if got, want := dst.Paste(src, grids.Loc{}).Render(), "A#x\n"; got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// A dense source fully set is pasted whole, growing the copy.
+	full := grids.DenseOf([][]string{{"y", "z"}})
+	pasted := dst.Paste(full, grids.Loc{2, 1})
+	if got, want := pasted.Bounds(), grids.NewBounds(0, 3, 0, 1); got != want {
+		t.Errorf("got bounds %v, want %v", got, want)
+	}
+	if v, ok := pasted.At(grids.Loc{3, 1}); !ok || v != "z" {
+		t.Errorf("got %q, %t at {3, 1}, want \"z\", true", v, ok)
+	}
+	if _, ok := pasted.At(grids.Loc{0, 1}); ok {
+		t.Errorf("got a value at {0, 1} which neither grid has")
+	}
+}
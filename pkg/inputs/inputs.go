@@ -0,0 +1,165 @@
+// Package inputs is a store of puzzle inputs from several Advent of Code
+// accounts, which all get inputs of their own, together with the answers
+// expected for them. A store is laid out as:
+//
+//	<account>/day1.txt
+//	<account>/day2.txt
+//	<account>/answers.json
+//
+// where answers.json holds the answers per day and part, like:
+//
+//	{
+//	  "1": {"1": 72511, "2": 212117},
+//	  "5": {"1": "MQTPGLLDN"}
+//	}
+//
+// A day or part without an answer is solved but not checked.
+package inputs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+)
+
+// Dir is where the store lives relative to the root of the repository.
+const Dir = "inputs"
+
+const answersFilename = "answers.json"
+
+// Answers is the expected answers of an account, by day and then part.
+type Answers map[int]map[int]answers.Answer
+
+// Input is the input of an account for a day.
+type Input struct {
+	Account string
+	Day     int
+	// Path is the path of the input within the store's file system.
+	Path string
+	// Answers is the expected answers by part.
+	Answers map[int]answers.Answer
+}
+
+// Store reads inputs from a file system laid out as described in the
+// package documentation, like a directory or files embedded in a binary.
+type Store struct {
+	fsys fs.FS
+}
+
+func New(fsys fs.FS) *Store {
+	return &Store{fsys: fsys}
+}
+
+func (s *Store) FS() fs.FS {
+	return s.fsys
+}
+
+// Accounts returns the accounts in the store in order. A store which
+// doesn't exist has no accounts.
+func (s *Store) Accounts() ([]string, error) {
+	entries, err := fs.ReadDir(s.fsys, ".")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing accounts: %w", err)
+	}
+
+	accounts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			accounts = append(accounts, e.Name())
+		}
+	}
+	return accounts, nil
+}
+
+// Path returns the path of the account's input for the day within the
+// store's file system.
+func Path(account string, day int) string {
+	return path.Join(account, fmt.Sprintf("day%d.txt", day))
+}
+
+func (s *Store) Open(account string, day int) (fs.File, error) {
+	return s.fsys.Open(Path(account, day))
+}
+
+// Answers reads the answers expected for the account's inputs. An account
+// without an answers file has no answers.
+func (s *Store) Answers(account string) (Answers, error) {
+	b, err := fs.ReadFile(s.fsys, path.Join(account, answersFilename))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Answers{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading answers of %s: %w", account, err)
+	}
+
+	var a Answers
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, fmt.Errorf("decoding answers of %s: %w", account, err)
+	}
+	return a, nil
+}
+
+// Inputs returns every input in the store, by account and then day.
+func (s *Store) Inputs() ([]Input, error) {
+	accounts, err := s.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]Input, 0)
+	for _, account := range accounts {
+		a, err := s.Answers(account)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := fs.ReadDir(s.fsys, account)
+		if err != nil {
+			return nil, fmt.Errorf("listing inputs of %s: %w", account, err)
+		}
+
+		days := make([]int, 0, len(entries))
+		for _, e := range entries {
+			var day int
+			if _, err := fmt.Sscanf(e.Name(), "day%d.txt", &day); err != nil || e.Name() != path.Base(Path(account, day)) {
+				continue
+			}
+			days = append(days, day)
+		}
+		sort.Ints(days)
+
+		for _, day := range days {
+			inputs = append(inputs, Input{
+				Account: account,
+				Day:     day,
+				Path:    Path(account, day),
+				Answers: a[day],
+			})
+		}
+	}
+	return inputs, nil
+}
+
+// InputsOf returns the inputs in the store for the day, by account.
+func (s *Store) InputsOf(day int) ([]Input, error) {
+	all, err := s.Inputs()
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]Input, 0, len(all))
+	for _, in := range all {
+		if in.Day == day {
+			inputs = append(inputs, in)
+		}
+	}
+	return inputs, nil
+}
@@ -0,0 +1,66 @@
+package inputs_test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/inputs"
+)
+
+func TestStore(t *testing.T) {
+	store := inputs.New(fstest.MapFS{
+		"alice/day1.txt":     {Data: []byte("1000\n")},
+		"alice/day10.txt":    {Data: []byte("noop\n")},
+		"alice/day2.txt":     {Data: []byte("A Y\n")},
+		"alice/answers.json": {Data: []byte(`{"1": {"1": 1000, "2": 1000}, "10": {"1": 0}}`)},
+		"alice/notes.txt":    {Data: []byte("not an input")},
+		"bob/day1.txt":       {Data: []byte("2000\n")},
+		"README.md":          {Data: []byte("not an account")},
+	})
+
+	accounts, err := store.Accounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(accounts, want) {
+		t.Errorf("got accounts %v, want %v", accounts, want)
+	}
+
+	got, err := store.Inputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []inputs.Input{
+		{Account: "alice", Day: 1, Path: "alice/day1.txt", Answers: map[int]answers.Answer{1: answers.Int(1000), 2: answers.Int(1000)}},
+		{Account: "alice", Day: 2, Path: "alice/day2.txt"},
+		{Account: "alice", Day: 10, Path: "alice/day10.txt", Answers: map[int]answers.Answer{1: answers.Int(0)}},
+		{Account: "bob", Day: 1, Path: "bob/day1.txt"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got inputs:\n%+v\nwant:\n%+v", got, want)
+	}
+
+	f, err := store.Open("bob", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if b, _ := io.ReadAll(f); string(b) != "2000\n" {
+		t.Errorf("got input %q, want bob's", b)
+	}
+}
+
+func TestStore_missing(t *testing.T) {
+	store := inputs.New(fstest.MapFS{})
+
+	got, err := store.Inputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v, want no inputs", got)
+	}
+}
@@ -0,0 +1,144 @@
+// Package parse helps puzzle parsers report exactly where an input is
+// malformed.
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is a parse failure at a position in a day's input. Line and
+// Column are 1-based and Column is 0 if the whole line is at fault.
+type Error struct {
+	Day    int
+	Line   int
+	Column int
+	// Text is the offending part of the line.
+	Text string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("day %d, line %d: %q: %v", e.Day, e.Line, e.Text, e.Err)
+	}
+	return fmt.Sprintf("day %d, line %d, column %d: %q: %v", e.Day, e.Line, e.Column, e.Text, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Scanner reads a day's input line by line like a bufio.Scanner, keeping
+// track of the line number to create errors with.
+type Scanner struct {
+	day     int
+	scanner *bufio.Scanner
+	line    int
+	text    string
+}
+
+func NewScanner(day int, reader io.Reader) *Scanner {
+	return &Scanner{day: day, scanner: bufio.NewScanner(reader)}
+}
+
+func (s *Scanner) Scan() bool {
+	if !s.scanner.Scan() {
+		return false
+	}
+	s.line++
+	s.text = s.scanner.Text()
+	return true
+}
+
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+func (s *Scanner) Bytes() []byte {
+	return s.scanner.Bytes()
+}
+
+// Line returns the number of the current line.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Err returns the error of the underlying scanner, if any.
+func (s *Scanner) Err() error {
+	if err := s.scanner.Err(); err != nil {
+		return &Error{Day: s.day, Line: s.line + 1, Err: err}
+	}
+	return nil
+}
+
+// Wrap returns err as an error at the first occurrence of text in the
+// current line, or at the whole line if text is empty or can't be found
+// in it. Text repeating an earlier part of the line is pointed out at
+// that part, so callers that know where text is use WrapColumn or
+// WrapField instead.
+func (s *Scanner) Wrap(text string, err error) error {
+	return At(s.day, s.line, s.text, text, err)
+}
+
+// WrapColumn returns err as an error at text in the given column of the
+// current line, for when the column is already known, like when walking
+// the line rune by rune.
+func (s *Scanner) WrapColumn(column int, text string, err error) error {
+	return &Error{Day: s.day, Line: s.line, Column: column, Text: text, Err: err}
+}
+
+// WrapField returns err as an error at the field of the current line.
+func (s *Scanner) WrapField(f Field, err error) error {
+	return s.WrapColumn(f.Column, f.Text, err)
+}
+
+// Errorf is like Wrap, creating the error from format and args, so it's
+// only for text that can't be found earlier in the line.
+func (s *Scanner) Errorf(text string, format string, args ...any) error {
+	return s.Wrap(text, fmt.Errorf(format, args...))
+}
+
+// At returns err as an error at the first occurrence of text in line,
+// which has the given line number, or at the whole line if text is empty
+// or can't be found in it.
+func At(day, lineNumber int, line, text string, err error) error {
+	idx := -1
+	if text != "" {
+		idx = strings.Index(line, text)
+	}
+	if idx < 0 {
+		return &Error{Day: day, Line: lineNumber, Text: line, Err: err}
+	}
+
+	return &Error{
+		Day:    day,
+		Line:   lineNumber,
+		Column: utf8.RuneCountInString(line[:idx]) + 1,
+		Text:   text,
+		Err:    err,
+	}
+}
+
+// Field is a part of a line and the 1-based column it starts at.
+type Field struct {
+	Text   string
+	Column int
+}
+
+// Split slices line into the fields separated by sep like strings.Split,
+// keeping track of the column each field starts at.
+func Split(line, sep string) []Field {
+	parts := strings.Split(line, sep)
+	fields := make([]Field, 0, len(parts))
+
+	column := 1
+	for _, part := range parts {
+		fields = append(fields, Field{Text: part, Column: column})
+		column += utf8.RuneCountInString(part) + utf8.RuneCountInString(sep)
+	}
+	return fields
+}
@@ -0,0 +1,71 @@
+package parse_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+)
+
+func TestScanner(t *testing.T) {
+	scanner := parse.NewScanner(4, strings.NewReader("1-2,3-4\n\n2-x,5-6\n"))
+
+	var err error
+	for scanner.Scan() && err == nil {
+		if scanner.Text() == "" {
+			continue
+		}
+		for _, f := range parse.Split(scanner.Text(), ",") {
+			_, to, _ := strings.Cut(f.Text, "-")
+			if _, atoiErr := strconv.Atoi(to); atoiErr != nil {
+				err = scanner.Wrap(to, atoiErr)
+				break
+			}
+		}
+	}
+
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, want a parse error", err)
+	}
+	want := &parse.Error{Day: 4, Line: 3, Column: 3, Text: "x", Err: perr.Err}
+	if !reflect.DeepEqual(perr, want) {
+		t.Errorf("got %+v, want %+v", perr, want)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("got error %v, want it to wrap %v", err, strconv.ErrSyntax)
+	}
+}
+
+func TestAt(t *testing.T) {
+	cause := errors.New("bad")
+
+	tests := []struct {
+		name string
+		line string
+		text string
+		want string
+	}{
+		{"text", "Sensor at x=1, y=é2", "é2", `day 15, line 7, column 18: "é2": bad`},
+		{"whole line", "Sensor at x=1", "", `day 15, line 7: "Sensor at x=1": bad`},
+		{"missing text", "Sensor at x=1", "y=", `day 15, line 7: "Sensor at x=1": bad`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse.At(15, 7, tt.line, tt.text, cause).Error(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	got := parse.Split("498,4 -> 498,6 -> 496,6", " -> ")
+	want := []parse.Field{{"498,4", 1}, {"498,6", 10}, {"496,6", 19}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,256 @@
+// Package profile profiles solver runs with runtime/pprof and
+// runtime/trace, and sums up pprof profiles per function so the hottest
+// functions can be shown without going through go tool pprof.
+package profile
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Outputs is where to write the profiles of a run, nil meaning that kind
+// of profile isn't collected.
+type Outputs struct {
+	CPU   io.Writer
+	Mem   io.Writer
+	Trace io.Writer
+}
+
+// Start starts the CPU profile and execution trace. The returned function
+// stops them and writes the allocation profile, and must be called once
+// the run being profiled is done.
+func Start(o Outputs) (stop func() error, err error) {
+	if o.CPU != nil {
+		if err := pprof.StartCPUProfile(o.CPU); err != nil {
+			return nil, fmt.Errorf("starting CPU profile: %w", err)
+		}
+	}
+	if o.Trace != nil {
+		if err := trace.Start(o.Trace); err != nil {
+			if o.CPU != nil {
+				pprof.StopCPUProfile()
+			}
+			return nil, fmt.Errorf("starting trace: %w", err)
+		}
+	}
+
+	return func() error {
+		if o.Trace != nil {
+			trace.Stop()
+		}
+		if o.CPU != nil {
+			pprof.StopCPUProfile()
+		}
+		if o.Mem != nil {
+			if err := WriteAllocs(o.Mem); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// WriteAllocs writes the allocation profile, which covers every
+// allocation made since the program started.
+func WriteAllocs(w io.Writer) error {
+	// The profile only includes allocations up to the last GC.
+	runtime.GC()
+	if err := pprof.Lookup("allocs").WriteTo(w, 0); err != nil {
+		return fmt.Errorf("writing allocation profile: %w", err)
+	}
+	return nil
+}
+
+// Function is what the samples of a profile add up to for one function.
+// Flat only counts the samples where the function itself was running,
+// while Cum also counts those where it was further up the stack.
+type Function struct {
+	Name string
+	Flat int64
+	Cum  int64
+}
+
+// Summary is the samples of one type in a profile summed up per function,
+// sorted by flat value with the largest first.
+type Summary struct {
+	SampleType string
+	Unit       string
+	Total      int64
+	Functions  []Function
+}
+
+// Summarize reads a pprof profile, gzipped or not, and sums up the values
+// of the given sample type per function, like alloc_space or cpu. The
+// samples of the profiler itself writing a profile, anything called from
+// runtime/pprof, are left out as they aren't part of the run.
+func Summarize(r io.Reader, sampleType string) (Summary, error) {
+	br := bufio.NewReader(r)
+	var data []byte
+	var err error
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, zerr := gzip.NewReader(br)
+		if zerr != nil {
+			return Summary{}, fmt.Errorf("decompressing profile: %w", zerr)
+		}
+		data, err = io.ReadAll(zr)
+	} else {
+		data, err = io.ReadAll(br)
+	}
+	if err != nil {
+		return Summary{}, fmt.Errorf("reading profile: %w", err)
+	}
+
+	p, err := parseProfile(data)
+	if err != nil {
+		return Summary{}, fmt.Errorf("parsing profile: %w", err)
+	}
+
+	index := -1
+	types := make([]string, 0, len(p.sampleTypes))
+	for i, st := range p.sampleTypes {
+		types = append(types, p.string(st.typ))
+		if p.string(st.typ) == sampleType {
+			index = i
+		}
+	}
+	if index == -1 {
+		return Summary{}, fmt.Errorf("profile has no %s samples, only %v", sampleType, types)
+	}
+
+	s := Summary{SampleType: sampleType, Unit: p.string(p.sampleTypes[index].unit)}
+	byName := make(map[string]*Function)
+	function := func(id uint64) *Function {
+		name := p.string(p.functions[id])
+		f, ok := byName[name]
+		if !ok {
+			f = &Function{Name: name}
+			byName[name] = f
+		}
+		return f
+	}
+
+	for _, sample := range p.samples {
+		if index >= len(sample.values) || sample.values[index] == 0 {
+			continue
+		}
+		if p.inProfiler(sample.locationIDs) {
+			continue
+		}
+		v := sample.values[index]
+		s.Total += v
+
+		seen := make(map[*Function]bool)
+		for i, locationID := range sample.locationIDs {
+			for j, functionID := range p.locations[locationID] {
+				f := function(functionID)
+				if i == 0 && j == 0 {
+					f.Flat += v
+				}
+				// Recursive functions are only counted once per sample.
+				if !seen[f] {
+					f.Cum += v
+					seen[f] = true
+				}
+			}
+		}
+	}
+
+	s.Functions = make([]Function, 0, len(byName))
+	for _, f := range byName {
+		s.Functions = append(s.Functions, *f)
+	}
+	s.sort()
+	return s, nil
+}
+
+// inProfiler returns whether a sample's stack goes through runtime/pprof.
+func (p *rawProfile) inProfiler(locationIDs []uint64) bool {
+	for _, locationID := range locationIDs {
+		for _, functionID := range p.locations[locationID] {
+			if strings.HasPrefix(p.string(p.functions[functionID]), "runtime/pprof.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Sub returns the summary of what was sampled since base was summarized,
+// for profiles like the allocation profile which only ever grow.
+func (s Summary) Sub(base Summary) Summary {
+	before := make(map[string]Function, len(base.Functions))
+	for _, f := range base.Functions {
+		before[f.Name] = f
+	}
+
+	diff := Summary{SampleType: s.SampleType, Unit: s.Unit, Total: s.Total - base.Total}
+	diff.Functions = make([]Function, 0, len(s.Functions))
+	for _, f := range s.Functions {
+		b := before[f.Name]
+		f.Flat -= b.Flat
+		f.Cum -= b.Cum
+		if f.Flat != 0 || f.Cum != 0 {
+			diff.Functions = append(diff.Functions, f)
+		}
+	}
+	diff.sort()
+	return diff
+}
+
+// Top returns the n functions with the largest flat values.
+func (s Summary) Top(n int) []Function {
+	if n > len(s.Functions) {
+		n = len(s.Functions)
+	}
+	return s.Functions[:n]
+}
+
+// Format formats a value of the summary's sample type in its unit.
+func (s Summary) Format(v int64) string {
+	switch s.Unit {
+	case "nanoseconds":
+		return time.Duration(v).String()
+	case "bytes":
+		return formatBytes(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (s Summary) sort() {
+	sort.Slice(s.Functions, func(i, j int) bool {
+		a, b := s.Functions[i], s.Functions[j]
+		if a.Flat != b.Flat {
+			return a.Flat > b.Flat
+		}
+		if a.Cum != b.Cum {
+			return a.Cum > b.Cum
+		}
+		return a.Name < b.Name
+	})
+}
+
+func formatBytes(v int64) string {
+	const unit = 1024
+	if v < unit && v > -unit {
+		return fmt.Sprintf("%dB", v)
+	}
+
+	f, suffix := float64(v), ""
+	for _, suffix = range []string{"kB", "MB", "GB", "TB"} {
+		f /= unit
+		if f < unit && f > -unit {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f%s", f, suffix)
+}
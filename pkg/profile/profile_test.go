@@ -0,0 +1,70 @@
+package profile_test
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/profile"
+)
+
+var sink [][]byte
+
+//go:noinline
+func allocateLots() {
+	for i := 0; i < 100; i++ {
+		sink = append(sink, make([]byte, 64<<10))
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+
+	var before bytes.Buffer
+	if err := profile.WriteAllocs(&before); err != nil {
+		t.Fatal(err)
+	}
+	allocateLots()
+	var after bytes.Buffer
+	if err := profile.WriteAllocs(&after); err != nil {
+		t.Fatal(err)
+	}
+
+	base, err := profile.Summarize(&before, "alloc_space")
+	if err != nil {
+		t.Fatalf("summarizing: %v", err)
+	}
+	s, err := profile.Summarize(bytes.NewReader(after.Bytes()), "alloc_space")
+	if err != nil {
+		t.Fatalf("summarizing: %v", err)
+	}
+	s = s.Sub(base)
+
+	if s.Unit != "bytes" {
+		t.Errorf("got unit %q, want bytes", s.Unit)
+	}
+
+	top := s.Top(1)
+	if len(top) != 1 || !strings.HasSuffix(top[0].Name, ".allocateLots") {
+		t.Fatalf("got top %+v, want allocateLots", top)
+	}
+	if want := int64(100 * 64 << 10); top[0].Flat < want {
+		t.Errorf("got %s allocated by allocateLots, want at least %s", s.Format(top[0].Flat), s.Format(want))
+	}
+	if top[0].Cum < top[0].Flat {
+		t.Errorf("got cum %d less than flat %d", top[0].Cum, top[0].Flat)
+	}
+
+	// Writing the profile before allocates too, but isn't part of the run.
+	for _, f := range s.Functions {
+		if strings.HasPrefix(f.Name, "runtime/pprof.") || strings.HasPrefix(f.Name, "compress/") {
+			t.Errorf("got %s allocated by the profiler's %s", s.Format(f.Flat), f.Name)
+		}
+	}
+
+	if _, err := profile.Summarize(bytes.NewReader(after.Bytes()), "cpu"); err == nil {
+		t.Errorf("got no error, want one for a sample type the profile doesn't have")
+	}
+}
@@ -0,0 +1,193 @@
+package profile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// The subset of the pprof profile.proto messages needed to add up the
+// samples per function. See
+// https://github.com/google/pprof/blob/main/proto/profile.proto
+type rawProfile struct {
+	sampleTypes []rawValueType
+	samples     []rawSample
+	locations   map[uint64][]uint64 // location id to function ids, leaf first
+	functions   map[uint64]int64    // function id to name in the string table
+	strings     []string
+}
+
+type rawValueType struct {
+	typ, unit int64
+}
+
+type rawSample struct {
+	locationIDs []uint64
+	values      []int64
+}
+
+func parseProfile(b []byte) (*rawProfile, error) {
+	p := &rawProfile{
+		locations: make(map[uint64][]uint64),
+		functions: make(map[uint64]int64),
+	}
+
+	err := walkFields(b, func(field int, wire int, v uint64, data []byte) error {
+		switch field {
+		case 1: // sample_type
+			var vt rawValueType
+			err := walkFields(data, func(field int, _ int, v uint64, _ []byte) error {
+				switch field {
+				case 1:
+					vt.typ = int64(v)
+				case 2:
+					vt.unit = int64(v)
+				}
+				return nil
+			})
+			p.sampleTypes = append(p.sampleTypes, vt)
+			return err
+		case 2: // sample
+			var s rawSample
+			err := walkFields(data, func(field int, wire int, v uint64, data []byte) error {
+				switch field {
+				case 1:
+					return appendVarints(&s.locationIDs, wire, v, data)
+				case 2:
+					var values []uint64
+					if err := appendVarints(&values, wire, v, data); err != nil {
+						return err
+					}
+					for _, v := range values {
+						s.values = append(s.values, int64(v))
+					}
+				}
+				return nil
+			})
+			p.samples = append(p.samples, s)
+			return err
+		case 4: // location
+			var id uint64
+			var functionIDs []uint64
+			err := walkFields(data, func(field int, _ int, v uint64, data []byte) error {
+				switch field {
+				case 1:
+					id = v
+				case 4: // line
+					return walkFields(data, func(field int, _ int, v uint64, _ []byte) error {
+						if field == 1 {
+							functionIDs = append(functionIDs, v)
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			p.locations[id] = functionIDs
+			return err
+		case 5: // function
+			var id uint64
+			var name int64
+			err := walkFields(data, func(field int, _ int, v uint64, _ []byte) error {
+				switch field {
+				case 1:
+					id = v
+				case 2:
+					name = int64(v)
+				}
+				return nil
+			})
+			p.functions[id] = name
+			return err
+		case 6: // string_table
+			p.strings = append(p.strings, string(data))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *rawProfile) string(i int64) string {
+	if i < 0 || i >= int64(len(p.strings)) {
+		return ""
+	}
+	return p.strings[i]
+}
+
+const (
+	wireVarint = 0
+	wire64     = 1
+	wireBytes  = 2
+	wire32     = 5
+)
+
+var errTruncated = errors.New("truncated profile")
+
+// walkFields calls fn with every field of the protobuf message in b, with
+// v set for varints and data set for length delimited fields.
+func walkFields(b []byte, fn func(field int, wire int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errTruncated
+		}
+		b = b[n:]
+
+		field, wire := int(key>>3), int(key&7)
+		var v uint64
+		var data []byte
+		switch wire {
+		case wireVarint:
+			v, n = binary.Uvarint(b)
+			if n <= 0 {
+				return errTruncated
+			}
+			b = b[n:]
+		case wire64:
+			if len(b) < 8 {
+				return errTruncated
+			}
+			b = b[8:]
+		case wire32:
+			if len(b) < 4 {
+				return errTruncated
+			}
+			b = b[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < length {
+				return errTruncated
+			}
+			data, b = b[n:n+int(length)], b[n+int(length):]
+		default:
+			return fmt.Errorf("unsupported wire type %d of field %d", wire, field)
+		}
+
+		if err := fn(field, wire, v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendVarints appends a repeated varint field, which is either a single
+// value or packed into a length delimited field.
+func appendVarints(dst *[]uint64, wire int, v uint64, data []byte) error {
+	if wire == wireVarint {
+		*dst = append(*dst, v)
+		return nil
+	}
+
+	for len(data) > 0 {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		*dst = append(*dst, v)
+		data = data[n:]
+	}
+	return nil
+}
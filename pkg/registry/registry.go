@@ -0,0 +1,144 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+)
+
+// DefaultVariant is the variant used for a day's main solution.
+// Other variants are alternative solvers, or the same solver set up
+// differently, for the same day and part.
+const DefaultVariant = "default"
+
+type Solver interface {
+	Solve(ctx context.Context, reader io.Reader) (answers.Answer, error)
+}
+
+type SolverFunc func(ctx context.Context, reader io.Reader) (answers.Answer, error)
+
+func (fn SolverFunc) Solve(ctx context.Context, reader io.Reader) (answers.Answer, error) {
+	return fn(ctx, reader)
+}
+
+// Of adapts the common Part1/Part2 signature to a Solver. The solver can't
+// be interrupted, so the context is only checked before starting.
+func Of[T answers.Value](fn func(reader io.Reader) (T, error)) Solver {
+	return OfContext(func(ctx context.Context, reader io.Reader) (T, error) {
+		var zero T
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		return fn(reader)
+	})
+}
+
+// OfContext adapts Part1/Part2 methods that take a context, which is
+// what solvers with long running searches do to be cancellable.
+func OfContext[T answers.Value](fn func(ctx context.Context, reader io.Reader) (T, error)) Solver {
+	return SolverFunc(func(ctx context.Context, reader io.Reader) (answers.Answer, error) {
+		v, err := fn(ctx, reader)
+		if err != nil {
+			return answers.Answer{}, err
+		}
+		return answers.Of(v), nil
+	})
+}
+
+type Key struct {
+	Day     int    `json:"day"`
+	Part    int    `json:"part"`
+	Variant string `json:"variant"`
+}
+
+func (k Key) String() string {
+	if k.Variant == DefaultVariant {
+		return fmt.Sprintf("day %d part %d", k.Day, k.Part)
+	}
+	return fmt.Sprintf("day %d part %d (%s)", k.Day, k.Part, k.Variant)
+}
+
+var (
+	mutex   sync.RWMutex
+	solvers = make(map[Key]Solver)
+)
+
+// Register registers the default solver for the day and part.
+// Like database/sql.Register, it panics if called twice for the same key.
+func Register(day, part int, solver Solver) {
+	RegisterVariant(day, part, DefaultVariant, solver)
+}
+
+func RegisterVariant(day, part int, variant string, solver Solver) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	key := Key{Day: day, Part: part, Variant: variant}
+	if solver == nil {
+		panic(fmt.Sprintf("registry: nil solver for %s", key))
+	}
+	if _, exists := solvers[key]; exists {
+		panic(fmt.Sprintf("registry: %s registered twice", key))
+	}
+	solvers[key] = solver
+}
+
+func Lookup(day, part int, variant string) (Solver, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	solver, ok := solvers[Key{Day: day, Part: part, Variant: variant}]
+	return solver, ok
+}
+
+// Keys returns every registered key ordered by day, part and variant
+// with the default variant first.
+func Keys() []Key {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	keys := make([]Key, 0, len(solvers))
+	for k := range solvers {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Part != b.Part {
+			return a.Part < b.Part
+		}
+		if (a.Variant == DefaultVariant) != (b.Variant == DefaultVariant) {
+			return a.Variant == DefaultVariant
+		}
+		return a.Variant < b.Variant
+	})
+
+	return keys
+}
+
+func Days() []int {
+	days := make([]int, 0)
+	for _, k := range Keys() {
+		if len(days) == 0 || days[len(days)-1] != k.Day {
+			days = append(days, k.Day)
+		}
+	}
+	return days
+}
+
+func Variants(day, part int) []string {
+	variants := make([]string, 0)
+	for _, k := range Keys() {
+		if k.Day == day && k.Part == part {
+			variants = append(variants, k.Variant)
+		}
+	}
+	return variants
+}
@@ -0,0 +1,72 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func TestRegistry(t *testing.T) {
+	// Using made up days to not collide with any real registrations.
+	const day = 101
+
+	lineCount := func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		return strings.Count(string(b), "\n"), err
+	}
+	registry.Register(day, 1, registry.Of(lineCount))
+	registry.RegisterVariant(day, 1, "b", registry.Of(lineCount))
+	registry.RegisterVariant(day, 1, "a", registry.Of(lineCount))
+	registry.Register(day, 2, registry.Of(lineCount))
+
+	t.Run("Lookup", func(t *testing.T) {
+		solver, ok := registry.Lookup(day, 1, "a")
+		if !ok {
+			t.Fatalf("got no solver, want one")
+		}
+		got, err := solver.Solve(context.Background(), strings.NewReader("a\nb\n"))
+		if err != nil {
+			t.Fatalf("solving: %v", err)
+		}
+		if want := answers.Int(2); !got.Equal(want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+
+		if _, ok := registry.Lookup(day, 3, registry.DefaultVariant); ok {
+			t.Errorf("got a solver for an unregistered part")
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		solver, _ := registry.Lookup(day, 1, registry.DefaultVariant)
+		if _, err := solver.Solve(ctx, strings.NewReader("a\n")); !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("Variants", func(t *testing.T) {
+		got := registry.Variants(day, 1)
+		want := []string{registry.DefaultVariant, "a", "b"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Register twice", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected a panic")
+			}
+		}()
+		registry.Register(day, 2, registry.Of(lineCount))
+	})
+}
@@ -0,0 +1,95 @@
+// Package report runs many solvers at once and presents how each of them
+// did compared to their golden answers.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+type Status string
+
+const (
+	StatusOK        Status = "ok"
+	StatusMismatch  Status = "mismatch"
+	StatusUnchecked Status = "unchecked"
+	StatusError     Status = "error"
+)
+
+type Row struct {
+	Key     registry.Key   `json:"key"`
+	Input   string         `json:"input"`
+	Account string         `json:"account,omitempty"`
+	Answer  answers.Answer `json:"answer"`
+	Want    answers.Answer `json:"want"`
+	Status  Status         `json:"status"`
+	Runtime time.Duration  `json:"runtimeNs"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type Report struct {
+	Rows []Row `json:"rows"`
+}
+
+// Failures counts the rows which either failed or got the wrong answer.
+func (r Report) Failures() int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Status == StatusError || row.Status == StatusMismatch {
+			n++
+		}
+	}
+	return n
+}
+
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func (r Report) WriteMarkdown(w io.Writer) error {
+	sb := &strings.Builder{}
+	sb.WriteString("| Day | Part | Variant | Input | Answer | Golden | Runtime | Error |\n")
+	sb.WriteString("|----:|-----:|---------|-------|--------|--------|--------:|-------|\n")
+	for _, row := range r.Rows {
+		fmt.Fprintf(
+			sb,
+			"| %d | %d | %s | %s | %s | %s | %s | %s |\n",
+			row.Key.Day, row.Key.Part, row.Key.Variant, inputName(row),
+			cell(row.Answer.String()), golden(row), row.Runtime.Round(time.Microsecond), cell(row.Error),
+		)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// inputName names the input by its filename, and account if it has one.
+func inputName(row Row) string {
+	if row.Account != "" {
+		return row.Account + "/" + filepath.Base(row.Input)
+	}
+	return filepath.Base(row.Input)
+}
+
+func golden(row Row) string {
+	if row.Status == StatusMismatch {
+		return fmt.Sprintf("mismatch, want %s", cell(row.Want.String()))
+	}
+	return string(row.Status)
+}
+
+// cell makes s safe to put in a table cell, keeping rendered answers on
+// separate lines.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
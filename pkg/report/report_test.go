@@ -0,0 +1,172 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/report"
+)
+
+func TestRun(t *testing.T) {
+	// Using made up days to not collide with any real registrations.
+	const day = 201
+
+	registry.Register(day, 1, registry.Of(func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		return len(b), err
+	}))
+	registry.Register(day, 2, registry.Of(func(reader io.Reader) (int, error) {
+		return 0, errors.New("broken")
+	}))
+	registry.RegisterVariant(day, 2, "slow", registry.OfContext(func(ctx context.Context, reader io.Reader) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}))
+	registry.RegisterVariant(day, 2, "panicky", registry.Of(func(reader io.Reader) (int, error) {
+		var lines []string
+		return len(lines[1]), nil
+	}))
+
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(input, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	part1 := registry.Key{Day: day, Part: 1, Variant: registry.DefaultVariant}
+	part2 := registry.Key{Day: day, Part: 2, Variant: registry.DefaultVariant}
+	slow := registry.Key{Day: day, Part: 2, Variant: "slow"}
+	panicky := registry.Key{Day: day, Part: 2, Variant: "panicky"}
+	tasks := []report.Task{
+		{Key: part1, Input: input, Want: answers.Int(3)},
+		{Key: part1, Input: input, Want: answers.Int(4)},
+		{Key: part1, Input: input},
+		{Key: part2, Input: input, Want: answers.Int(3)},
+		{Key: slow, Input: input},
+		{Key: panicky, Input: input},
+		{Key: part1, Input: filepath.Join(t.TempDir(), "missing.txt")},
+		{Key: part1, Input: "alice/day201.txt", Want: answers.Int(5), Account: "alice", FS: fstest.MapFS{
+			"alice/day201.txt": {Data: []byte("abcde")},
+		}},
+	}
+
+	r := report.Run(context.Background(), tasks, 3, 10*time.Millisecond)
+
+	want := []report.Status{
+		report.StatusOK,
+		report.StatusMismatch,
+		report.StatusUnchecked,
+		report.StatusError,
+		report.StatusError,
+		report.StatusError,
+		report.StatusError,
+		report.StatusOK,
+	}
+	if len(r.Rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(r.Rows), len(want))
+	}
+	for i, row := range r.Rows {
+		if row.Key != tasks[i].Key || row.Input != tasks[i].Input {
+			t.Errorf("row %d is for %s %s, want %s %s", i, row.Key, row.Input, tasks[i].Key, tasks[i].Input)
+		}
+		if row.Status != want[i] {
+			t.Errorf("row %d has status %q, want %q (error %q)", i, row.Status, want[i], row.Error)
+		}
+	}
+	if got := r.Failures(); got != 5 {
+		t.Errorf("got %d failures, want 5", got)
+	}
+	if got := r.Rows[4].Error; !strings.Contains(got, context.DeadlineExceeded.Error()) {
+		t.Errorf("got error %q for the slow solver, want a timeout", got)
+	}
+	if got := r.Rows[5].Error; !strings.Contains(got, "panicked") {
+		t.Errorf("got error %q for the panicking solver, want the panic", got)
+	}
+}
+
+func TestReport_WriteMarkdown(t *testing.T) {
+	r := report.Report{Rows: []report.Row{
+		{
+			Key:     registry.Key{Day: 5, Part: 1, Variant: registry.DefaultVariant},
+			Input:   "puzzles/day5/input.txt",
+			Answer:  answers.String("CMZ"),
+			Want:    answers.String("MCD"),
+			Status:  report.StatusMismatch,
+			Runtime: 1500 * time.Microsecond,
+		},
+		{
+			Key:     registry.Key{Day: 10, Part: 2, Variant: registry.DefaultVariant},
+			Input:   "puzzles/day10/input.txt",
+			Answer:  answers.Rendered("#.\n.#"),
+			Status:  report.StatusUnchecked,
+			Runtime: time.Millisecond,
+		},
+		{
+			Key:    registry.Key{Day: 16, Part: 2, Variant: registry.DefaultVariant},
+			Input:  "puzzles/day16/input.txt",
+			Status: report.StatusError,
+			Error:  "a | b",
+		},
+		{
+			Key:     registry.Key{Day: 1, Part: 1, Variant: registry.DefaultVariant},
+			Input:   "alice/day1.txt",
+			Account: "alice",
+			Answer:  answers.Int(24000),
+			Want:    answers.Int(24000),
+			Status:  report.StatusOK,
+			Runtime: time.Millisecond,
+		},
+	}}
+
+	buf := &bytes.Buffer{}
+	if err := r.WriteMarkdown(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"| Day | Part | Variant | Input | Answer | Golden | Runtime | Error |",
+		"|----:|-----:|---------|-------|--------|--------|--------:|-------|",
+		"| 5 | 1 | default | input.txt | CMZ | mismatch, want MCD | 1.5ms |  |",
+		"| 10 | 2 | default | input.txt | #.<br>.# | unchecked | 1ms |  |",
+		`| 16 | 2 | default | input.txt |  | error | 0s | a \| b |`,
+		"| 1 | 1 | default | alice/day1.txt | 24000 | ok | 1ms |  |",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	r := report.Report{Rows: []report.Row{{
+		Key:     registry.Key{Day: 1, Part: 1, Variant: registry.DefaultVariant},
+		Input:   "input.txt",
+		Answer:  answers.Int(24000),
+		Want:    answers.Int(24000),
+		Status:  report.StatusOK,
+		Runtime: time.Millisecond,
+	}}}
+
+	buf := &bytes.Buffer{}
+	if err := r.WriteJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var got report.Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding report: %v", err)
+	}
+	if len(got.Rows) != 1 || got.Rows[0] != r.Rows[0] {
+		t.Errorf("got %+v, want %+v", got, r)
+	}
+}
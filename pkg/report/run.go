@@ -0,0 +1,110 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+// Task is a solver to run on an input. A zero Want means there's no
+// golden answer to compare with.
+type Task struct {
+	Key   registry.Key
+	Input string
+	Want  answers.Answer
+	// Account is the account the input belongs to, if it's from an input
+	// store, in which case Input is a path within FS.
+	Account string
+	FS      fs.FS
+}
+
+// Run solves every task on a pool of workers, giving each solve at most
+// timeout if it's positive, and returns the rows in the order of tasks.
+func Run(ctx context.Context, tasks []Task, workers int, timeout time.Duration) Report {
+	if workers < 1 {
+		workers = 1
+	}
+
+	rows := make([]Row, len(tasks))
+	indexes := make(chan int)
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				rows[i] = runTask(ctx, tasks[i], timeout)
+			}
+		}()
+	}
+
+	for i := range tasks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return Report{Rows: rows}
+}
+
+func runTask(ctx context.Context, task Task, timeout time.Duration) Row {
+	row := Row{Key: task.Key, Input: task.Input, Account: task.Account, Want: task.Want}
+
+	answer, elapsed, err := solve(ctx, task, timeout)
+	row.Answer, row.Runtime = answer, elapsed
+	switch {
+	case err != nil:
+		row.Status, row.Error = StatusError, err.Error()
+	case task.Want.IsZero():
+		row.Status = StatusUnchecked
+	case !answer.Equal(task.Want):
+		row.Status = StatusMismatch
+	default:
+		row.Status = StatusOK
+	}
+	return row
+}
+
+// solve solves the task's input, turning a panic into an error as one
+// malformed input shouldn't take down the rest of the run.
+func solve(ctx context.Context, task Task, timeout time.Duration) (answer answers.Answer, elapsed time.Duration, err error) {
+	solver, ok := registry.Lookup(task.Key.Day, task.Key.Part, task.Key.Variant)
+	if !ok {
+		return answers.Answer{}, 0, fmt.Errorf("no solver registered for %s", task.Key)
+	}
+
+	var f io.ReadCloser
+	if task.FS != nil {
+		f, err = task.FS.Open(task.Input)
+	} else {
+		f, err = os.Open(task.Input)
+	}
+	if err != nil {
+		return answers.Answer{}, 0, fmt.Errorf("opening input: %w", err)
+	}
+	defer f.Close()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	defer func() {
+		if v := recover(); v != nil {
+			answer, elapsed, err = answers.Answer{}, time.Since(start), fmt.Errorf("solver panicked: %v", v)
+		}
+	}()
+
+	answer, err = solver.Solve(ctx, f)
+	return answer, time.Since(start), err
+}
@@ -0,0 +1,126 @@
+// Package scaffold generates the skeleton of a new day's puzzle package
+// following the layout of the existing puzzles/dayN packages.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+type file struct {
+	path     string
+	template string
+}
+
+type dayData struct {
+	Day     int
+	Package string
+}
+
+// Generate creates puzzles/dayN under root and registers it in
+// puzzles/all. It returns the paths of the created and updated files.
+func Generate(root string, day int) ([]string, error) {
+	if day < 1 || day > 25 {
+		return nil, fmt.Errorf("illegal day %d, must be between 1 and 25", day)
+	}
+
+	data := dayData{Day: day, Package: fmt.Sprintf("day%d", day)}
+	dir := filepath.Join(root, "puzzles", data.Package)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", dir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("checking %s: %w", dir, err)
+	}
+
+	files := []file{
+		{filepath.Join(dir, data.Package+".go"), "day.go.tmpl"},
+		{filepath.Join(dir, "register.go"), "register.go.tmpl"},
+		{filepath.Join(dir, data.Package+"_test.go"), "day_test.go.tmpl"},
+		{filepath.Join(dir, "testdata", "answers.json"), "answers.json.tmpl"},
+		{filepath.Join(dir, "testdata", "example.txt"), ""},
+		{filepath.Join(dir, "instructions.txt"), ""},
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "testdata"), 0o755); err != nil {
+		return nil, fmt.Errorf("creating directories: %w", err)
+	}
+
+	written := make([]string, 0, len(files)+1)
+	for _, f := range files {
+		if err := writeTemplate(f.path, f.template, data); err != nil {
+			return nil, err
+		}
+		written = append(written, f.path)
+	}
+
+	allPath, err := writeAll(root)
+	if err != nil {
+		return nil, fmt.Errorf("registering %s: %w", data.Package, err)
+	}
+	written = append(written, allPath)
+
+	return written, nil
+}
+
+var dayDirPattern = regexp.MustCompile(`^day\d+$`)
+
+// writeAll regenerates puzzles/all/all.go from the day directories
+// so that the new day is registered with the runner.
+func writeAll(root string) (string, error) {
+	puzzlesDir := filepath.Join(root, "puzzles")
+	entries, err := os.ReadDir(puzzlesDir)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", puzzlesDir, err)
+	}
+
+	packages := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() && dayDirPattern.MatchString(e.Name()) {
+			packages = append(packages, e.Name())
+		}
+	}
+	sort.Strings(packages)
+
+	path := filepath.Join(puzzlesDir, "all", "all.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("creating directories: %w", err)
+	}
+	return path, writeTemplate(path, "all.go.tmpl", packages)
+}
+
+func writeTemplate(path, name string, data any) error {
+	buf := &bytes.Buffer{}
+	if name != "" {
+		if err := templates.ExecuteTemplate(buf, name, data); err != nil {
+			return fmt.Errorf("executing %s: %w", name, err)
+		}
+	}
+
+	content := buf.Bytes()
+	if strings.HasSuffix(path, ".go") {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w", path, err)
+		}
+		content = formatted
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package scaffold_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/scaffold"
+)
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+
+	// Mirror the real day directories so the generated all.go can be
+	// compared with the one in the repository.
+	realPuzzles := relative.Filepath("../../puzzles")
+	entries, err := os.ReadDir(realPuzzles)
+	if err != nil {
+		t.Fatalf("reading puzzles: %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "day") {
+			if err := os.MkdirAll(filepath.Join(root, "puzzles", e.Name()), 0o755); err != nil {
+				t.Fatalf("creating dir: %v", err)
+			}
+		}
+	}
+
+	written, err := scaffold.Generate(root, 25)
+	if err != nil {
+		t.Fatalf("generating: %v", err)
+	}
+	if got, want := len(written), 7; got != want {
+		t.Errorf("got %d written files, want %d", got, want)
+	}
+
+	// The day's real inputs go in the input store, checked by the golden
+	// tests through the accounts case.
+	cases, err := golden.Load(filepath.Join(root, "puzzles", "day25", golden.Dir))
+	if err != nil {
+		t.Fatalf("loading the generated answers: %v", err)
+	}
+	if len(cases) != 2 || !cases[1].Accounts {
+		t.Errorf("got cases %+v, want the example and the accounts", cases)
+	}
+	if _, err := os.Stat(filepath.Join(root, "puzzles", "day25", "input.txt")); err == nil {
+		t.Errorf("got an input.txt, want the input in the input store")
+	}
+
+	fset := token.NewFileSet()
+	for _, path := range written {
+		if filepath.Ext(path) != ".go" {
+			continue
+		}
+		if _, err := parser.ParseFile(fset, path, nil, parser.AllErrors); err != nil {
+			t.Errorf("parsing generated %s: %v", path, err)
+		}
+	}
+
+	gotAll, err := os.ReadFile(filepath.Join(root, "puzzles", "all", "all.go"))
+	if err != nil {
+		t.Fatalf("reading generated all.go: %v", err)
+	}
+	wantAll, err := os.ReadFile(filepath.Join(realPuzzles, "all", "all.go"))
+	if err != nil {
+		t.Fatalf("reading all.go: %v", err)
+	}
+
+	newImport := "\t_ \"github.com/kristofferostlund/adventofcode-2022/puzzles/day25\"\n"
+	if !strings.Contains(string(gotAll), newImport) {
+		t.Errorf("generated all.go doesn't import day25:\n%s", gotAll)
+	}
+	if withoutNew := strings.Replace(string(gotAll), newImport, "", 1); withoutNew != string(wantAll) {
+		t.Errorf("generated all.go differs from the one in the repository:\n%s", gotAll)
+	}
+
+	if _, err := scaffold.Generate(root, 25); err == nil {
+		t.Errorf("expected an error when the day already exists")
+	}
+}
@@ -0,0 +1,197 @@
+// Package server exposes the registered solvers over HTTP.
+//
+// A part is solved by posting the puzzle input to /days/{n}/parts/{p},
+// optionally with a ?variant= query parameter, which responds with the
+// answer and how long it took to solve as JSON.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+type Response struct {
+	Key     registry.Key   `json:"key"`
+	Answer  answers.Answer `json:"answer"`
+	Runtime time.Duration  `json:"runtimeNs"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type Server struct {
+	timeout  time.Duration
+	maxInput int64
+	slots    chan struct{}
+}
+
+// New returns a server giving each request at most timeout, including
+// time spent waiting for one of the maxConcurrent solve slots, and
+// rejecting inputs larger than maxInput bytes. A timeout of 0 means
+// requests are only limited by the client giving up.
+func New(timeout time.Duration, maxConcurrent int, maxInput int64) *Server {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &Server{
+		timeout:  timeout,
+		maxInput: maxInput,
+		slots:    make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	day, part, ok := parsePath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("not found, use POST /days/{n}/parts/{p}"))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	variant := r.URL.Query().Get("variant")
+	if variant == "" {
+		variant = registry.DefaultVariant
+	}
+	key := registry.Key{Day: day, Part: part, Variant: variant}
+
+	solver, ok := registry.Lookup(day, part, variant)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no solver registered for %s", key))
+		return
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxInput))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("input is larger than %d bytes", tooLarge.Limit))
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("reading input: %w", err))
+		return
+	}
+
+	ctx := r.Context()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("waiting for a free solver: %w", ctx.Err()))
+		return
+	}
+
+	// Solvers that don't take a context keep on solving past the timeout,
+	// so the slot is only freed once they're done.
+	solved := make(chan solveResult, 1)
+	go func() {
+		defer func() { <-s.slots }()
+		solved <- solve(ctx, key, solver, input)
+	}()
+
+	var res solveResult
+	select {
+	case res = <-solved:
+	case <-ctx.Done():
+		res = solveResult{err: ctx.Err()}
+	}
+
+	var panicked *panicError
+	switch {
+	case errors.As(res.err, &panicked):
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("solving %s: %w", key, res.err))
+		return
+	case errors.Is(res.err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, fmt.Errorf("solving %s: %w", key, res.err))
+		return
+	case errors.Is(res.err, context.Canceled):
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("solving %s: %w", key, res.err))
+		return
+	case res.err != nil:
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("solving %s: %w", key, res.err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{Key: key, Answer: res.answer, Runtime: res.elapsed})
+}
+
+type solveResult struct {
+	answer  answers.Answer
+	elapsed time.Duration
+	err     error
+}
+
+// panicError is a panic recovered from a solver.
+type panicError struct {
+	value any
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("solver panicked: %v", e.value)
+}
+
+// solve solves the input, turning a panic into an error as a malformed
+// input shouldn't take down the server.
+func solve(ctx context.Context, key registry.Key, solver registry.Solver, input []byte) (res solveResult) {
+	defer func() {
+		if v := recover(); v != nil {
+			log.Printf("solving %s panicked: %v\n%s", key, v, debug.Stack())
+			res = solveResult{err: &panicError{value: v}}
+		}
+	}()
+
+	start := time.Now()
+	answer, err := solver.Solve(ctx, bytes.NewReader(input))
+	return solveResult{answer: answer, elapsed: time.Since(start), err: err}
+}
+
+// parsePath parses paths like /days/16/parts/2.
+func parsePath(path string) (day, part int, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != 4 || segments[0] != "days" || segments[2] != "parts" {
+		return 0, 0, false
+	}
+
+	day, err := strconv.Atoi(segments[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	part, err = strconv.Atoi(segments[3])
+	if err != nil {
+		return 0, 0, false
+	}
+	return day, part, true
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
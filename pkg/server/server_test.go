@@ -0,0 +1,164 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/server"
+)
+
+// Using made up days to not collide with any real registrations.
+const (
+	countDay   = 301
+	slowDay    = 302
+	blockedDay = 303
+	panicDay   = 304
+)
+
+// Set by the tests using the blocked day's solver.
+var blockedStarted, unblock chan struct{}
+
+func init() {
+	registry.Register(countDay, 1, registry.Of(func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		return len(b), err
+	}))
+	registry.RegisterVariant(countDay, 1, "lines", registry.Of(func(reader io.Reader) (int, error) {
+		b, err := io.ReadAll(reader)
+		return strings.Count(string(b), "\n"), err
+	}))
+	registry.Register(slowDay, 1, registry.OfContext(func(ctx context.Context, reader io.Reader) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}))
+	registry.Register(blockedDay, 1, registry.Of(func(reader io.Reader) (int, error) {
+		blockedStarted <- struct{}{}
+		<-unblock
+		return 1, nil
+	}))
+	registry.Register(panicDay, 1, registry.Of(func(reader io.Reader) (int, error) {
+		var lines []string
+		return len(lines[:3]), nil
+	}))
+}
+
+func TestServer(t *testing.T) {
+	ts := httptest.NewServer(server.New(50*time.Millisecond, 1, 16))
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantAnswer answers.Answer
+	}{
+		{"solves", http.MethodPost, "/days/301/parts/1", "a\nb\n", http.StatusOK, answers.Int(4)},
+		{"variant", http.MethodPost, "/days/301/parts/1?variant=lines", "a\nb\n", http.StatusOK, answers.Int(2)},
+		{"unknown part", http.MethodPost, "/days/301/parts/2", "", http.StatusNotFound, answers.Answer{}},
+		{"malformed path", http.MethodPost, "/days/one/parts/1", "", http.StatusNotFound, answers.Answer{}},
+		{"wrong method", http.MethodGet, "/days/301/parts/1", "", http.StatusMethodNotAllowed, answers.Answer{}},
+		{"too large", http.MethodPost, "/days/301/parts/1", strings.Repeat("a", 17), http.StatusRequestEntityTooLarge, answers.Answer{}},
+		{"timeout", http.MethodPost, "/days/302/parts/1", "", http.StatusGatewayTimeout, answers.Answer{}},
+		{"panic", http.MethodPost, "/days/304/parts/1", "", http.StatusInternalServerError, answers.Answer{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				b, _ := io.ReadAll(resp.Body)
+				t.Fatalf("got status %d, want %d: %s", resp.StatusCode, tt.wantStatus, b)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got server.Response
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if !got.Answer.Equal(tt.wantAnswer) {
+				t.Errorf("got answer %v, want %v", got.Answer, tt.wantAnswer)
+			}
+		})
+	}
+}
+
+func TestServer_concurrencyCap(t *testing.T) {
+	srv := server.New(50*time.Millisecond, 1, 16)
+	blockedStarted, unblock = make(chan struct{}), make(chan struct{})
+
+	blocked := make(chan int)
+	go func() {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/days/303/parts/1", nil))
+		blocked <- rec.Code
+	}()
+	<-blockedStarted
+
+	// The only slot is taken, so this one times out waiting for it.
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/days/301/parts/1", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d while the slot was taken, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	close(unblock)
+	<-blocked
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/days/301/parts/1", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("got status %d once the slot was free, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestServer_timeoutIgnored(t *testing.T) {
+	srv := server.New(50*time.Millisecond, 1, 16)
+	blockedStarted, unblock = make(chan struct{}, 1), make(chan struct{})
+
+	// The blocked day's solver doesn't take a context, so it keeps going
+	// past the timeout until it's unblocked.
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/days/303/parts/1", nil))
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusGatewayTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("got a response after %s, want it at the timeout", elapsed)
+	}
+
+	var got server.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil || got.Error == "" {
+		t.Errorf("got error response %+v, %v, want an error", got, err)
+	}
+
+	// The solver still has the slot until it's done.
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/days/301/parts/1", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d while the solver was still running, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	close(unblock)
+}
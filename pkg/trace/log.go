@@ -0,0 +1,45 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log is a tracer writing every event as it happens, one per line with
+// snapshots indented below their name.
+type Log struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+}
+
+func NewLog(w io.Writer) *Log {
+	return &Log{w: w, start: time.Now()}
+}
+
+func (l *Log) Count(name string, value int) {
+	l.printf("%s=%d", name, value)
+}
+
+func (l *Log) Logf(format string, args ...any) {
+	l.printf(format, args...)
+}
+
+func (l *Log) Snapshot(name string, render func() string) {
+	rendered := strings.TrimRight(render(), "\n")
+	l.printf("%s:\n\t%s", name, strings.ReplaceAll(rendered, "\n", "\n\t"))
+}
+
+func (l *Log) PhaseDone(name string, elapsed time.Duration) {
+	l.printf("%s took %s", name, elapsed)
+}
+
+func (l *Log) printf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fmt.Fprintf(l.w, "[%9s] %s\n", time.Since(l.start).Round(time.Millisecond), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,87 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress is a tracer keeping a single live line on a terminal up to
+// date with the latest counters and the last finished phase. Messages
+// and snapshots are ignored as they don't fit on a line.
+type Progress struct {
+	mu       sync.Mutex
+	w        io.Writer
+	interval time.Duration
+	drawn    time.Time
+	counters map[string]int
+	phase    string
+}
+
+// NewProgress returns a tracer redrawing the line on w at most once per
+// interval. Close must be called once done to finish the line.
+func NewProgress(w io.Writer, interval time.Duration) *Progress {
+	return &Progress{w: w, interval: interval, counters: make(map[string]int)}
+}
+
+func (p *Progress) Count(name string, value int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.counters[name] = value
+	p.draw(false)
+}
+
+func (p *Progress) Logf(string, ...any) {}
+
+func (p *Progress) Snapshot(string, func() string) {}
+
+func (p *Progress) PhaseDone(name string, elapsed time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.phase = fmt.Sprintf("%s took %s", name, elapsed.Round(time.Microsecond))
+	p.draw(true)
+}
+
+// Close draws the final state of the line and moves past it.
+func (p *Progress) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.drawn.IsZero() {
+		return nil
+	}
+	p.draw(true)
+	_, err := fmt.Fprintln(p.w)
+	return err
+}
+
+func (p *Progress) draw(force bool) {
+	now := time.Now()
+	if !force && now.Sub(p.drawn) < p.interval {
+		return
+	}
+	p.drawn = now
+
+	names := make([]string, 0, len(p.counters))
+	for name := range p.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%d", name, p.counters[name]))
+	}
+	if p.phase != "" {
+		parts = append(parts, p.phase)
+	}
+
+	// \r moves back to the start of the line and \x1b[K clears what's
+	// left of the previous draw.
+	fmt.Fprintf(p.w, "\r\x1b[K%s", strings.Join(parts, " "))
+}
@@ -0,0 +1,94 @@
+package trace
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer receives events from solvers as they run. Solvers get hold of
+// one with From and shouldn't care about what, if anything, it does with
+// the events.
+type Tracer interface {
+	// Count reports the current value of a counter, like the number of
+	// states explored or the length of a queue.
+	Count(name string, value int)
+	// Logf reports a free form message.
+	Logf(format string, args ...any)
+	// Snapshot reports a rendering of the solver's state, like a grid.
+	// render is only called if the tracer has somewhere to show it,
+	// so it's fine for it to be expensive.
+	Snapshot(name string, render func() string)
+	// PhaseDone reports that a phase of the solver, like parsing the
+	// input, has finished after running for elapsed.
+	PhaseDone(name string, elapsed time.Duration)
+}
+
+// Phase starts timing a phase of the solver, reporting it to the tracer
+// once the returned function is called.
+//
+//	defer trace.Phase(tr, "parsing")()
+func Phase(tr Tracer, name string) func() {
+	start := time.Now()
+	return func() {
+		tr.PhaseDone(name, time.Since(start))
+	}
+}
+
+type contextKey struct{}
+
+// With returns a copy of ctx carrying tr.
+func With(ctx context.Context, tr Tracer) context.Context {
+	return context.WithValue(ctx, contextKey{}, tr)
+}
+
+// From returns the tracer carried by ctx, or Nop if there is none.
+func From(ctx context.Context) Tracer {
+	if tr, ok := ctx.Value(contextKey{}).(Tracer); ok {
+		return tr
+	}
+	return Nop
+}
+
+// Nop is a tracer which discards all events.
+var Nop Tracer = nop{}
+
+type nop struct{}
+
+func (nop) Count(string, int)               {}
+func (nop) Logf(string, ...any)             {}
+func (nop) Snapshot(string, func() string)  {}
+func (nop) PhaseDone(string, time.Duration) {}
+
+// Multi returns a tracer passing every event on to all of tracers.
+func Multi(tracers ...Tracer) Tracer {
+	if len(tracers) == 1 {
+		return tracers[0]
+	}
+	return multi(tracers)
+}
+
+type multi []Tracer
+
+func (m multi) Count(name string, value int) {
+	for _, tr := range m {
+		tr.Count(name, value)
+	}
+}
+
+func (m multi) Logf(format string, args ...any) {
+	for _, tr := range m {
+		tr.Logf(format, args...)
+	}
+}
+
+func (m multi) Snapshot(name string, render func() string) {
+	for _, tr := range m {
+		tr.Snapshot(name, render)
+	}
+}
+
+func (m multi) PhaseDone(name string, elapsed time.Duration) {
+	for _, tr := range m {
+		tr.PhaseDone(name, elapsed)
+	}
+}
@@ -0,0 +1,69 @@
+package trace_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/trace"
+)
+
+func TestFrom(t *testing.T) {
+	if got := trace.From(context.Background()); got != trace.Nop {
+		t.Errorf("got %v, want the nop tracer", got)
+	}
+
+	tr := trace.NewLog(&bytes.Buffer{})
+	if got := trace.From(trace.With(context.Background(), tr)); got != tr {
+		t.Errorf("got %v, want %v", got, tr)
+	}
+}
+
+func TestLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tr := trace.NewLog(buf)
+
+	tr.Count("states", 12)
+	tr.Logf("checking %s", "sensor")
+	tr.Snapshot("grid", func() string { return "#.\n.#\n" })
+	tr.PhaseDone("parsing", 2*time.Millisecond)
+
+	// Strip the timestamps to not depend on how fast this runs.
+	got := regexp.MustCompile(`(?m)^\[[^]]*\] `).ReplaceAllString(buf.String(), "")
+	want := strings.Join([]string{
+		"states=12",
+		"checking sensor",
+		"grid:",
+		"\t#.",
+		"\t.#",
+		"parsing took 2ms",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tr := trace.NewProgress(buf, time.Hour)
+
+	tr.Count("states", 1)
+	tr.Count("states", 2) // Throttled, drawn on close.
+	tr.Count("queue", 5)
+	tr.Snapshot("grid", func() string {
+		t.Errorf("rendered a snapshot which can't be shown")
+		return ""
+	})
+	if err := tr.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "\r\x1b[Kstates=1\r\x1b[Kqueue=5 states=2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+// Package all registers every day's solvers with the registry.
+package all
+
+import (
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day1"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day10"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day11"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day12"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day13"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day14"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day15"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day16"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day17"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day18"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day2"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day3"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day4"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day5"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day6"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day7"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day8"
+	_ "github.com/kristofferostlund/adventofcode-2022/puzzles/day9"
+)
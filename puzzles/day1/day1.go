@@ -1,11 +1,12 @@
 package day1
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"sort"
 	"strconv"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 type Puzzle struct{}
@@ -36,7 +37,7 @@ func (d Puzzle) Part2(reader io.Reader) (int, error) {
 func (Puzzle) parseGroups(reader io.Reader) ([]int, error) {
 	groups := make([]int, 0)
 
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(1, reader)
 	currGroup := 0
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -52,10 +53,13 @@ func (Puzzle) parseGroups(reader io.Reader) ([]int, error) {
 
 		val, err := strconv.Atoi(line)
 		if err != nil {
-			return nil, fmt.Errorf("parsing line %q: %w", line, err)
+			return nil, scanner.Wrap(line, fmt.Errorf("parsing calories: %w", err))
 		}
 		currGroup += val
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	if currGroup > 0 {
 		groups = append(groups, currGroup)
@@ -1,89 +1,22 @@
 package day1_test
 
 import (
-	"os"
-	"strings"
+	"fmt"
 	"testing"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
-	"github.com/kristofferostlund/adventofcode-2022/puzzles/day1"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/difftest"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
 )
 
 func TestPuzzle(t *testing.T) {
-	exampleInput := `1000
-2000
-3000
-
-4000
-
-5000
-6000
-
-7000
-8000
-9000
-
-10000
-`
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 24000
-			got, err := day1.Puzzle{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 72511
-			got, err := day1.Puzzle{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
-
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 45000
-			got, err := day1.Puzzle{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 212117
-			got, err := day1.Puzzle{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
+	golden.Run(t, 1)
+}
 
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
+func TestPuzzle_differential(t *testing.T) {
+	for _, part := range []int{1, 2} {
+		t.Run(fmt.Sprintf("Part%d", part), func(t *testing.T) {
+			difftest.Run(t, 1, part, registry.DefaultVariant, "stream", 200, 50)
 		})
-	})
+	}
 }
@@ -0,0 +1,39 @@
+package day1
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseGroups(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		groups, err := Puzzle{}.parseGroups(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+		if !sort.SliceIsSorted(groups, func(i, j int) bool { return groups[i] > groups[j] }) {
+			t.Fatalf("got groups %v, want them from the most calories", groups)
+		}
+
+		// Each group written as an elf carrying all of it must give the
+		// same groups back.
+		sb := &strings.Builder{}
+		for _, g := range groups {
+			fmt.Fprintf(sb, "%d\n\n", g)
+		}
+		again, err := Puzzle{}.parseGroups(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatalf("parsing the groups %v: %v", groups, err)
+		}
+		if !reflect.DeepEqual(again, groups) {
+			t.Errorf("got groups %v after a round trip, want %v", again, groups)
+		}
+	})
+}
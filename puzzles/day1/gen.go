@@ -0,0 +1,27 @@
+package day1
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generate writes the calories carried by size elves, and at least the
+// three part 2 adds up.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	if size < 3 {
+		size = 3
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		for j := 1 + r.Intn(15); j > 0; j-- {
+			fmt.Fprintln(bw, 1000+r.Intn(9000))
+		}
+	}
+	return bw.Flush()
+}
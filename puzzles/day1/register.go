@@ -0,0 +1,16 @@
+package day1
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(1, 1, registry.Of(Puzzle{}.Part1))
+	registry.Register(1, 2, registry.Of(Puzzle{}.Part2))
+
+	registry.RegisterVariant(1, 1, "stream", registry.Of(StreamSolver{}.Part1))
+	registry.RegisterVariant(1, 2, "stream", registry.Of(StreamSolver{}.Part2))
+
+	gen.Register(1, generate)
+}
@@ -0,0 +1,87 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+)
+
+// StreamSolver only keeps track of the elves carrying the most calories
+// as it reads the input, instead of collecting and sorting every elf.
+type StreamSolver struct{}
+
+func (s StreamSolver) Part1(reader io.Reader) (int, error) {
+	top, err := s.topGroups(reader, 1)
+	if err != nil {
+		return 0, fmt.Errorf("parsing groups: %w", err)
+	}
+
+	return top[0], nil
+}
+
+func (s StreamSolver) Part2(reader io.Reader) (int, error) {
+	top, err := s.topGroups(reader, 3)
+	if err != nil {
+		return 0, fmt.Errorf("parsing groups: %w", err)
+	}
+
+	sum := 0
+	for _, v := range top {
+		sum += v
+	}
+
+	return sum, nil
+}
+
+// topGroups returns the n largest groups of calories, largest first.
+func (StreamSolver) topGroups(reader io.Reader, n int) ([]int, error) {
+	top := make([]int, 0, n+1)
+	groups := 0
+	add := func(group int) {
+		groups++
+		i := len(top)
+		for i > 0 && top[i-1] < group {
+			i--
+		}
+		if i == n {
+			return
+		}
+		top = append(top, 0)
+		copy(top[i+1:], top[i:])
+		top[i] = group
+		if len(top) > n {
+			top = top[:n]
+		}
+	}
+
+	scanner := parse.NewScanner(1, reader)
+	currGroup := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			add(currGroup)
+			currGroup = 0
+			continue
+		}
+
+		val, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, scanner.Wrap(line, fmt.Errorf("parsing calories: %w", err))
+		}
+		currGroup += val
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	if currGroup > 0 {
+		add(currGroup)
+	}
+
+	if groups < n {
+		return nil, fmt.Errorf("found %d group(s), want at least %d", groups, n)
+	}
+	return top, nil
+}
@@ -1,11 +1,12 @@
 package day10
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 const (
@@ -126,34 +127,45 @@ func shouldRenderX(i, x, max int) bool {
 
 func parseOps(reader io.Reader) ([]operation, error) {
 	ops := make([]operation, 0)
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(10, reader)
 	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
+		if scanner.Text() == "" {
 			continue
 		}
 
-		cmd := strings.Split(line, " ")
-		if len(cmd) == 0 {
-			return nil, fmt.Errorf("malformed line: %q", line)
-		}
-
-		switch cmd[0] {
-		case cmdNoop:
-			ops = append(ops, operation{cmd: cmdNoop})
-		case cmdAddX:
-			if len(cmd) != 2 {
-				return nil, fmt.Errorf("malformed line: %q", line)
-			}
-			v, err := strconv.Atoi(cmd[1])
-			if err != nil {
-				return nil, fmt.Errorf("parsing value: %w", err)
-			}
-			ops = append(ops, operation{cmd: cmdAddX, val: v})
-		default:
-			return nil, fmt.Errorf("illegal op: %q", cmd[0])
+		op, err := parseOp(scanner)
+		if err != nil {
+			return nil, err
 		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
 	return ops, nil
 }
+
+// parseOp parses the operation on the scanner's current line.
+func parseOp(scanner *parse.Scanner) (operation, error) {
+	cmd := parse.Split(scanner.Text(), " ")
+
+	switch cmd[0].Text {
+	case cmdNoop:
+		if len(cmd) != 1 {
+			return operation{}, scanner.Errorf("", "malformed line, %s takes no value", cmdNoop)
+		}
+		return operation{cmd: cmdNoop}, nil
+	case cmdAddX:
+		if len(cmd) != 2 {
+			return operation{}, scanner.Errorf("", "malformed line, %s takes exactly one value", cmdAddX)
+		}
+		v, err := strconv.Atoi(cmd[1].Text)
+		if err != nil {
+			return operation{}, scanner.WrapField(cmd[1], fmt.Errorf("parsing value: %w", err))
+		}
+		return operation{cmd: cmdAddX, val: v}, nil
+	default:
+		return operation{}, scanner.WrapField(cmd[0], fmt.Errorf("illegal op: %q", cmd[0].Text))
+	}
+}
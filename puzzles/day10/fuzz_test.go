@@ -0,0 +1,38 @@
+package day10
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseOps(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		ops, err := parseOps(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		sb := &strings.Builder{}
+		for _, op := range ops {
+			if op.cmd == cmdNoop {
+				fmt.Fprintln(sb, cmdNoop)
+				continue
+			}
+			fmt.Fprintf(sb, "%s %d\n", op.cmd, op.val)
+		}
+		again, err := parseOps(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatalf("parsing %q, the operations %v: %v", sb, ops, err)
+		}
+		if !reflect.DeepEqual(again, ops) {
+			t.Errorf("got operations %v after a round trip, want %v", again, ops)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package day10
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generate writes size instructions, keeping the sprite close enough to
+// the screen for the CRT to draw something.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	x := 1
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		if r.Intn(3) == 0 {
+			fmt.Fprintln(bw, cmdNoop)
+			continue
+		}
+
+		v := r.Intn(11) - 5
+		if x+v < -1 || x+v > 40 {
+			v = -v
+		}
+		x += v
+		fmt.Fprintf(bw, "%s %d\n", cmdAddX, v)
+	}
+	return bw.Flush()
+}
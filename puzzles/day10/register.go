@@ -0,0 +1,38 @@
+package day10
+
+import (
+	"context"
+	"io"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/answers"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(10, 1, registry.Of(Puzzle{}.Part1))
+	registry.Register(10, 2, rendered(Puzzle{}.Part2))
+
+	registry.RegisterVariant(10, 1, "stream", registry.Of(StreamSolver{}.Part1))
+	registry.RegisterVariant(10, 2, "stream", rendered(StreamSolver{}.Part2))
+
+	gen.Register(10, generate)
+}
+
+// rendered adapts a part 2 which renders its answer to a solver.
+func rendered(part2 func(reader io.Reader, onRender func(str string)) error) registry.Solver {
+	return registry.SolverFunc(func(ctx context.Context, reader io.Reader) (answers.Answer, error) {
+		if err := ctx.Err(); err != nil {
+			return answers.Answer{}, err
+		}
+
+		var rendered string
+		onRender := func(str string) {
+			rendered = str
+		}
+		if err := part2(reader, onRender); err != nil {
+			return answers.Answer{}, err
+		}
+		return answers.Rendered(rendered), nil
+	})
+}
@@ -0,0 +1,97 @@
+package day10
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+)
+
+// StreamSolver runs each operation as soon as it's read instead of
+// parsing the whole program first, so its memory use doesn't grow with
+// the length of the program.
+type StreamSolver struct{}
+
+func (s StreamSolver) Part1(reader io.Reader) (int, error) {
+	counter := 0
+	signal := func(c, x int) {
+		if (c-20)%40 == 0 {
+			counter += x * c
+		}
+	}
+
+	c, x, err := s.run(reader, signal)
+	if err != nil {
+		return 0, err
+	}
+	// Puzzle.Part1 also checks the cycle after the last operation.
+	signal(c, x)
+
+	return counter, nil
+}
+
+func (s StreamSolver) Part2(reader io.Reader, onRender func(str string)) error {
+	rows := [6][40]rune{}
+	rowi, linei := 0, 0
+
+	lineLen := len(rows[0])
+	rowLen := len(rows)
+
+	_, _, err := s.run(reader, func(_, x int) {
+		if shouldRenderX(linei, x, lineLen) {
+			rows[rowi][linei] = '#'
+		} else {
+			rows[rowi][linei] = '.'
+		}
+
+		if linei+1 == lineLen {
+			rowi = (rowi + 1) % rowLen
+		}
+		linei = (linei + 1) % lineLen
+	})
+	if err != nil {
+		return err
+	}
+
+	sb := &strings.Builder{}
+	for _, row := range rows {
+		for _, r := range row {
+			sb.WriteRune(r)
+		}
+		sb.WriteRune('\n')
+	}
+
+	onRender(sb.String())
+
+	return nil
+}
+
+// run calls onCycle with the value of x during every cycle of the program,
+// and returns the cycle and value of x once the program is done.
+func (StreamSolver) run(reader io.Reader, onCycle func(c, x int)) (int, int, error) {
+	x, c := 1, 1
+
+	scanner := parse.NewScanner(10, reader)
+	for scanner.Scan() {
+		if scanner.Text() == "" {
+			continue
+		}
+
+		op, err := parseOp(scanner)
+		if err != nil {
+			return 0, 0, fmt.Errorf("parsing operation: %w", err)
+		}
+
+		for i := 0; i < cmdCycles[op.cmd]; i++ {
+			onCycle(c, x)
+			c++
+		}
+		x += op.val
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, fmt.Errorf("reading input: %w", err)
+	}
+
+	return c, x, nil
+}
@@ -1,14 +1,16 @@
 package day11
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/sets"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/stacks"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day11/math"
@@ -99,53 +101,109 @@ func (op *Operation) Exec(mutateValue func(int) int) (value int, destination int
 	return val, dest
 }
 
+// monkeyKeys are the keys every monkey in the input must have.
+var monkeyKeys = map[string]bool{
+	"Starting items": true,
+	"Operation":      true,
+	"Test":           true,
+	"If true":        true,
+	"If false":       true,
+}
+
+// rawField is a value from the input and the line it's from, to point
+// out where it is if it turns out to be malformed.
+type rawField struct {
+	value parse.Field
+	line  int
+	text  string
+}
+
+func (f rawField) wrap(err error) error {
+	if f.value.Text == "" {
+		return parse.At(11, f.line, f.text, "", err)
+	}
+	return &parse.Error{Day: 11, Line: f.line, Column: f.value.Column, Text: f.value.Text, Err: err}
+}
+
+// trimmedField returns text without the space around it, as a field of a
+// line where text starts in the given column.
+func trimmedField(text string, column int) parse.Field {
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	column += utf8.RuneCountInString(text[:len(text)-len(trimmed)])
+	return parse.Field{Text: strings.TrimRightFunc(trimmed, unicode.IsSpace), Column: column}
+}
+
 func parseInput(reader io.Reader) ([]*Operation, error) {
-	rawOps := make([]map[string]string, 0)
-	current := make(map[string]string, 5)
+	rawOps := make([]map[string]rawField, 0)
+	current := make(map[string]rawField, len(monkeyKeys))
 
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(11, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
 		if strings.HasPrefix(line, "Monkey") {
+			if len(current) > 0 {
+				return nil, scanner.Errorf("", "previous monkey only has %d of %d fields", len(current), len(monkeyKeys))
+			}
 			continue
 		}
 
-		key, value, ok := strings.Cut(line, ":")
+		keyText, valueText, ok := strings.Cut(line, ":")
 		if !ok {
-			return nil, fmt.Errorf("malformed line: %q", line)
+			return nil, scanner.Errorf("", "malformed line, want a key and a value separated by a colon")
+		}
+		keyField := trimmedField(keyText, 1)
+		key := keyField.Text
+		if !monkeyKeys[key] {
+			return nil, scanner.WrapField(keyField, fmt.Errorf("unknown key %q", key))
+		}
+		if _, seen := current[key]; seen {
+			return nil, scanner.WrapField(keyField, fmt.Errorf("duplicate key %q", key))
 		}
-		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		value := trimmedField(valueText, utf8.RuneCountInString(keyText)+2)
+		current[key] = rawField{value: value, line: scanner.Line(), text: line}
 
-		if len(current) == 5 {
+		if len(current) == len(monkeyKeys) {
 			rawOps = append(rawOps, current)
-			current = make(map[string]string, 5)
+			current = make(map[string]rawField, len(monkeyKeys))
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(current) > 0 {
+		return nil, scanner.Errorf("", "last monkey only has %d of %d fields", len(current), len(monkeyKeys))
+	}
 
 	ops := make([]*Operation, 0, len(rawOps))
 	for _, rawOp := range rawOps {
-		startingItems, err := parseStartingItems(rawOp["Starting items"])
+		startingItems, err := parseStartingItems(rawOp["Starting items"].value.Text)
 		if err != nil {
-			return nil, fmt.Errorf("parsing starting items: %w", err)
+			return nil, rawOp["Starting items"].wrap(fmt.Errorf("parsing starting items: %w", err))
 		}
 
-		opFunc, err := prepareOperationFunc(rawOp["Operation"])
+		opFunc, err := prepareOperationFunc(rawOp["Operation"].value.Text)
 		if err != nil {
-			return nil, fmt.Errorf("preparing operation func: %w", err)
+			return nil, rawOp["Operation"].wrap(fmt.Errorf("preparing operation func: %w", err))
 		}
 
-		divisor, err := parseTestDivisor(rawOp["Test"])
+		divisor, err := parseTestDivisor(rawOp["Test"].value.Text)
 		if err != nil {
-			return nil, fmt.Errorf("preparing test func: %w", err)
+			return nil, rawOp["Test"].wrap(fmt.Errorf("preparing test func: %w", err))
 		}
 
-		destinations, err := parseDestinations(rawOp["If true"], rawOp["If false"])
+		ifTrue, err := parseDestination(rawOp["If true"].value.Text)
+		if err != nil {
+			return nil, rawOp["If true"].wrap(fmt.Errorf("parsing if-true outcome: %w", err))
+		}
+		ifFalse, err := parseDestination(rawOp["If false"].value.Text)
 		if err != nil {
-			return nil, fmt.Errorf("parsing outcomes: %w", err)
+			return nil, rawOp["If false"].wrap(fmt.Errorf("parsing if-false outcome: %w", err))
 		}
+		destinations := map[bool]int{true: ifTrue, false: ifFalse}
 
 		ops = append(ops, &Operation{
 			items:        stacks.Of(startingItems),
@@ -155,6 +213,18 @@ func parseInput(reader io.Reader) ([]*Operation, error) {
 		})
 	}
 
+	if len(ops) < 2 {
+		return nil, fmt.Errorf("got %d monkeys, want at least 2 for there to be any monkey business", len(ops))
+	}
+	for i, rawOp := range rawOps {
+		for _, key := range []string{"If true", "If false"} {
+			// A monkey throwing to itself would keep juggling forever.
+			if dest := ops[i].destinations[key == "If true"]; dest < 0 || dest >= len(ops) || dest == i {
+				return nil, rawOp[key].wrap(fmt.Errorf("can't throw to monkey %d from monkey %d of %d", dest, i, len(ops)))
+			}
+		}
+	}
+
 	return ops, nil
 }
 
@@ -229,38 +299,23 @@ func parseTestDivisor(raw string) (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("parsing test value: %w", err)
 	}
+	if testVal <= 0 {
+		return 0, fmt.Errorf("got test value %d, want it to be positive", testVal)
+	}
 
 	return testVal, nil
 }
 
-func parseDestinations(rawIfTrue, rawIfFalse string) (map[bool]int, error) {
-	if rawIfTrue == "" || rawIfFalse == "" {
-		return nil, fmt.Errorf("malformed input: %q, %q", rawIfTrue, rawIfFalse)
-	}
-
-	parseDest := func(rawStr string) (int, error) {
-		_, destStr, ok := strings.Cut(rawStr, "throw to monkey")
-		if !ok {
-			return 0, fmt.Errorf("malformed dest string %q", rawStr)
-		}
-		dest, err := strconv.Atoi(strings.TrimSpace(destStr))
-		if err != nil {
-			return 0, fmt.Errorf("parsing dest string: %w", err)
-		}
-		return dest, nil
-	}
-
-	ifTrue, err := parseDest(rawIfTrue)
-	if err != nil {
-		return nil, fmt.Errorf("parsing if-true string: %w", err)
+func parseDestination(raw string) (int, error) {
+	_, destStr, ok := strings.Cut(raw, "throw to monkey")
+	if !ok {
+		return 0, fmt.Errorf("malformed dest string %q", raw)
 	}
-
-	ifFalse, err := parseDest(rawIfFalse)
+	dest, err := strconv.Atoi(strings.TrimSpace(destStr))
 	if err != nil {
-		return nil, fmt.Errorf("parsing if-false string: %w", err)
+		return 0, fmt.Errorf("parsing dest string: %w", err)
 	}
-
-	return map[bool]int{true: ifTrue, false: ifFalse}, nil
+	return dest, nil
 }
 
 func copyMap[K comparable, V any](in map[K]V) map[K]V {
@@ -1,104 +1,35 @@
 package day11_test
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day11"
 )
 
 func TestPuzzle(t *testing.T) {
-	exampleInput := `
-Monkey 0:
-  Starting items: 79, 98
-  Operation: new = old * 19
-  Test: divisible by 23
-    If true: throw to monkey 2
-    If false: throw to monkey 3
-
-Monkey 1:
-  Starting items: 54, 65, 75, 74
-  Operation: new = old + 6
-  Test: divisible by 19
-    If true: throw to monkey 2
-    If false: throw to monkey 0
-
-Monkey 2:
-  Starting items: 79, 60, 97
-  Operation: new = old * old
-  Test: divisible by 13
-    If true: throw to monkey 1
-    If false: throw to monkey 3
-
-Monkey 3:
-  Starting items: 74
-  Operation: new = old + 3
-  Test: divisible by 17
-    If true: throw to monkey 0
-    If false: throw to monkey 1
-`
-
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			var want int = 10605
-			got, err := day11.Puzzle{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			var want int = 117640
-			got, err := day11.Puzzle{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
-
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			var want int = 2713310158
-			got, err := day11.Puzzle{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			var want int = 30616425600
-			got, err := day11.Puzzle{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
+	golden.Run(t, 11)
+}
 
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
+func TestPuzzle_malformed(t *testing.T) {
+	example, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The value is in the key too, but it's the value that's malformed.
+	input := strings.Replace(string(example), "If true: throw to monkey 2", "If true: t", 1)
+
+	_, err = day11.Puzzle{}.Part1(strings.NewReader(input))
+
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, want a parse error", err)
+	}
+	if perr.Line != 5 || perr.Column != 14 || perr.Text != "t" {
+		t.Errorf("got error at line %d, column %d, text %q, want line 5, column 14, text %q", perr.Line, perr.Column, perr.Text, "t")
+	}
 }
@@ -0,0 +1,32 @@
+package day11
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		ops, err := parseInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+		items := func() int {
+			n := 0
+			for _, op := range ops {
+				n += op.items.Len()
+			}
+			return n
+		}
+		// The monkeys only throw the items between them, so a round of
+		// anything parsed neither loses nor makes up any.
+		before := items()
+		Puzzle{}.getMonkeyBusiness(ops, 1, func(val int) int { return val / 3 })
+		if after := items(); after != before {
+			t.Errorf("got %d items after a round, want the %d there were", after, before)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package day11
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	gomath "math"
+	"math/rand"
+	"strings"
+)
+
+// generate writes notes on size monkeys, and at least 2. At most one of
+// them squares the worry levels, and layouts where part 1's worry levels
+// get too large to keep track of are thrown away for new ones.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	if size < 2 {
+		size = 2
+	}
+
+	for attempt := 0; attempt < 100; attempt++ {
+		monkeys := randomMonkeys(r, size)
+		if !overflows(monkeys) {
+			bw := bufio.NewWriter(w)
+			for i, m := range monkeys {
+				if i > 0 {
+					fmt.Fprintln(bw)
+				}
+				m.write(bw, i)
+			}
+			return bw.Flush()
+		}
+	}
+	return errors.New("couldn't come up with monkeys keeping the worry levels in check")
+}
+
+type genMonkey struct {
+	items    []int
+	operator string
+	// operand is the value to add or multiply by, 0 meaning old.
+	operand int
+	divisor int
+	ifTrue  int
+	ifFalse int
+}
+
+var genDivisors = []int{2, 3, 5, 7, 11, 13, 17, 19, 23}
+
+func randomMonkeys(r *rand.Rand, n int) []genMonkey {
+	squaring := r.Intn(n)
+
+	monkeys := make([]genMonkey, n)
+	for i := range monkeys {
+		m := genMonkey{divisor: genDivisors[r.Intn(len(genDivisors))]}
+		for j := 1 + r.Intn(8); j > 0; j-- {
+			m.items = append(m.items, 50+r.Intn(50))
+		}
+
+		switch {
+		case i == squaring && r.Intn(2) == 0:
+			m.operator = "*"
+		case r.Intn(2) == 0:
+			m.operator, m.operand = "*", 2+r.Intn(18)
+		default:
+			m.operator, m.operand = "+", 1+r.Intn(8)
+		}
+
+		// Monkeys to throw to other than itself, two different ones unless
+		// there are only two monkeys.
+		m.ifTrue = otherMonkey(r, n, i, -1)
+		m.ifFalse = otherMonkey(r, n, i, m.ifTrue)
+		monkeys[i] = m
+	}
+	return monkeys
+}
+
+// otherMonkey picks a monkey out of n that's not self or other, unless
+// there are only two monkeys and other is the only option.
+func otherMonkey(r *rand.Rand, n, self, other int) int {
+	for {
+		m := r.Intn(n)
+		if m != self && (m != other || n == 2) {
+			return m
+		}
+	}
+}
+
+// overflows simulates part 1 to see if any worry level gets too large.
+func overflows(monkeys []genMonkey) bool {
+	items := make([][]int, len(monkeys))
+	for i, m := range monkeys {
+		items[i] = append([]int(nil), m.items...)
+	}
+
+	for round := 0; round < 20; round++ {
+		for i, m := range monkeys {
+			for _, old := range items[i] {
+				operand := m.operand
+				if operand == 0 {
+					operand = old
+				}
+
+				var value int
+				if m.operator == "+" {
+					value = old + operand
+				} else if operand > 0 && old > gomath.MaxInt/operand {
+					return true
+				} else {
+					value = old * operand
+				}
+				value /= 3
+
+				dest := m.ifFalse
+				if value%m.divisor == 0 {
+					dest = m.ifTrue
+				}
+				items[dest] = append(items[dest], value)
+			}
+			items[i] = items[i][:0]
+		}
+	}
+	return false
+}
+
+func (m genMonkey) write(w io.Writer, i int) {
+	items := make([]string, 0, len(m.items))
+	for _, item := range m.items {
+		items = append(items, fmt.Sprint(item))
+	}
+	operand := "old"
+	if m.operand != 0 {
+		operand = fmt.Sprint(m.operand)
+	}
+
+	fmt.Fprintf(w, "Monkey %d:\n", i)
+	fmt.Fprintf(w, "  Starting items: %s\n", strings.Join(items, ", "))
+	fmt.Fprintf(w, "  Operation: new = old %s %s\n", m.operator, operand)
+	fmt.Fprintf(w, "  Test: divisible by %d\n", m.divisor)
+	fmt.Fprintf(w, "    If true: throw to monkey %d\n", m.ifTrue)
+	fmt.Fprintf(w, "    If false: throw to monkey %d\n", m.ifFalse)
+}
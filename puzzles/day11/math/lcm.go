@@ -1,10 +1,11 @@
 package math
 
-import "fmt"
-
 func LCMSlice(integers []int) int {
-	if len(integers) < 2 {
-		panic(fmt.Sprintf("no common multiple for %d numbers", len(integers)))
+	switch len(integers) {
+	case 0:
+		panic("no common multiple for 0 numbers")
+	case 1:
+		return integers[0]
 	}
 
 	return lcm(integers[0], integers[1], integers[2:]...)
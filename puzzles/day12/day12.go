@@ -1,159 +1,111 @@
 package day12
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"math"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/dijkstra"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
 )
 
 type Puzzle struct{}
 
-func (p Puzzle) Part1(reader io.Reader) (int, error) {
+func (p Puzzle) Part1(ctx context.Context, reader io.Reader) (int, error) {
 	grid, start, dest, err := readInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing grid: %w", err)
 	}
 
-	graph, err := p.setupGraph(grid)
-	if err != nil {
-		return 0, fmt.Errorf("setting up graph: %w", err)
-	}
-
-	cost, _, ok := graph.ShortestPath(start, dest)
-	if !ok {
-		return 0, fmt.Errorf("couldn't find a path from %s to %s", start, dest)
-	}
-
-	return cost, nil
+	return shortestRoute(ctx, grid, []grids.Loc{start}, dest)
 }
 
-func (p Puzzle) Part2(reader io.Reader) (int, error) {
+func (p Puzzle) Part2(ctx context.Context, reader io.Reader) (int, error) {
 	grid, _, dest, err := readInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing grid: %w", err)
 	}
 
-	graph, err := p.setupGraph(grid)
-	if err != nil {
-		return 0, fmt.Errorf("setting up graph: %w", err)
-	}
-
 	startLocs := make([]grids.Loc, 0)
-	for _, loc := range grid.Locs() {
-		val, _ := grid.AtLoc(loc)
+	for _, loc := range grid.Bounds().Locs() {
+		val, _ := grid.At(loc)
 		if val == int('a') {
 			startLocs = append(startLocs, loc)
 		}
 	}
 
-	smallest := math.MaxInt64
-	for _, start := range startLocs {
-		cost, _, ok := graph.ShortestPath(start, dest)
-		if !ok {
-			return 0, fmt.Errorf("couldn't find a path from %s to %s", start, dest)
-		}
-		if cost < smallest {
-			smallest = cost
-		}
+	return shortestRoute(ctx, grid, startLocs, dest)
+}
+
+// shortestRoute returns the number of steps of the shortest route to dest
+// from any of starts.
+func shortestRoute(ctx context.Context, grid grids.Cells[int], starts []grids.Loc, dest grids.Loc) (int, error) {
+	search := grids.BFS(grid.Bounds(), starts, func(from, to grids.Loc) bool {
+		val, _ := grid.At(from)
+		nextVal, _ := grid.At(to)
+		// "at most one higher than the elevation of your current square"
+		return nextVal-val <= 1
+	})
+
+	route, ok := search.Path(dest)
+	if !ok {
+		return 0, fmt.Errorf("couldn't find a path to %s", dest)
 	}
+	explainRoute(explain.From(ctx), grid, route)
 
-	return smallest, nil
+	return len(route) - 1, nil
 }
 
-func (Puzzle) setupGraph(grid Grid) (*dijkstra.Graph[grids.Loc], error) {
-	graph := dijkstra.NewGraph[grids.Loc]()
-	for _, l := range grid.Locs() {
-		graph.AddNode(dijkstra.NewNode(l))
+// explainRoute draws the route, which goes from the start to the
+// destination.
+func explainRoute(e *explain.Explanation, grid grids.Cells[int], route []grids.Loc) {
+	if e == nil {
+		return
 	}
 
-	steps := [][2]int{{-1, 0}, {0, -1}, {1, 0}, {0, 1}}
-	for _, node := range graph.Nodes {
-		loc := node.Value()
-		val, _ := grid.AtLoc(loc)
-
-		for _, step := range steps {
-			next := loc.Add(step)
-			nextVal, ok := grid.AtLoc(next)
-			if !ok {
-				// Out of bounds
-				continue
-			}
-
-			// "at most one higher than the elevation of your current square"
-			if nextVal-val <= 1 {
-				other := graph.GetNode(next)
-				if other == nil {
-					return nil, fmt.Errorf("no node found for %s", next)
-				}
-				graph.AddEdge(node, other, 1)
+	drawn := grids.NewDense(grid.Bounds(), ".")
+	arrows := map[grids.Direction]string{grids.Up: "^", grids.Right: ">", grids.Down: "v", grids.Left: "<"}
+	for i, loc := range route[:len(route)-1] {
+		for _, d := range grids.Directions {
+			if loc.Add(d.Vector()) == route[i+1] {
+				drawn.Set(loc, arrows[d])
 			}
 		}
 	}
+	drawn.Set(route[len(route)-1], "E")
 
-	return graph, nil
-}
-
-type Grid [][]int
-
-func (g Grid) AtLoc(loc grids.Loc) (int, bool) {
-	x, y := loc[0], loc[1]
-	return g.At(x, y)
+	start := route[0]
+	e.Addf("start", "%d,%d", start[0], start[1])
+	e.Addf("steps", "%d", len(route)-1)
+	e.Add("route", drawn.Render())
 }
 
-func (g Grid) At(x, y int) (int, bool) {
-	if y < 0 || y >= len(g) {
-		return 0, false
+func readInput(reader io.Reader) (*grids.Dense[int], grids.Loc, grids.Loc, error) {
+	grid, markers, err := grids.Parse(12, reader, elevation, 'S', 'E')
+	if err != nil {
+		return nil, grids.Loc{}, grids.Loc{}, err
 	}
 
-	if x < 0 || x >= len(g[y]) {
-		return 0, false
+	start, hasStart := markers['S']
+	dest, hasDest := markers['E']
+	if !hasStart || !hasDest {
+		return nil, start, dest, fmt.Errorf("missing start (S) or destination (E)")
 	}
 
-	return g[y][x], true
-}
-
-func (g Grid) Locs() []grids.Loc {
-	locs := make([]grids.Loc, len(g)*len(g[0]))
-	for y, row := range g {
-		for x := range row {
-			loc := grids.Loc{x, y}
-			locs = append(locs, loc)
-		}
-	}
-	return locs
+	return grid, start, dest, nil
 }
 
-func readInput(reader io.Reader) (Grid, grids.Loc, grids.Loc, error) {
-	var start, dest grids.Loc
-	var grid [][]int
-
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		row := make([]int, 0, len(line))
-		for i, r := range line {
-			switch r {
-			case 'S':
-				start = grids.Loc{i, len(grid)}
-				row = append(row, int('a'))
-			case 'E':
-				dest = grids.Loc{i, len(grid)}
-				row = append(row, int('z'))
-			default:
-				row = append(row, int(r))
-			}
-		}
-
-		grid = append(grid, row)
+// elevation returns the elevation of a square, where the start has
+// elevation a and the destination elevation z.
+func elevation(r rune) (int, bool) {
+	switch {
+	case r == 'S':
+		return int('a'), true
+	case r == 'E':
+		return int('z'), true
+	case 'a' <= r && r <= 'z':
+		return int(r), true
+	default:
+		return 0, false
 	}
-
-	return Grid(grid), start, dest, nil
 }
@@ -1,82 +1,76 @@
 package day12_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"strings"
 	"testing"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day12"
 )
 
 func TestPuzzle(t *testing.T) {
-	exampleInput := `
-Sabqponm
-abcryxxl
-accszExk
-acctuvwj
-abdefghi
-`
-
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 31
-			got, err := day12.Puzzle{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 352
-			got, err := day12.Puzzle{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
+	golden.Run(t, 12)
+}
 
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
+func TestPuzzle_malformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		line   int
+		column int
+	}{
+		{"illegal height", "Sabqponm\nabcryxxl\naccs?xk\n", 3, 5},
+		{"second start", "Sabqponm\nabcSyxxl\n", 2, 4},
+		{"second destination", "SabEponm\nabcryExl\n", 2, 6},
+		{"short row", "Sabqponm\nabcE\n", 2, 0},
+		{"long row", "Sabqponm\nabcryxxlE\n", 2, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := day12.Puzzle{}.Part1(context.Background(), strings.NewReader(tt.input))
 
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 29
-			got, err := day12.Puzzle{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
+			var perr *parse.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("got error %v, want a parse error", err)
 			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
+			if perr.Line != tt.line || perr.Column != tt.column {
+				t.Errorf("got error at line %d, column %d, want line %d, column %d", perr.Line, perr.Column, tt.line, tt.column)
 			}
 		})
+	}
+}
 
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
+func TestPuzzle_explain(t *testing.T) {
+	f, err := os.Open("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
 
-			want := 345
-			got, err := day12.Puzzle{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
+	ctx, e := explain.With(context.Background())
+	if _, err := (day12.Puzzle{}).Part1(ctx, f); err != nil {
+		t.Fatal(err)
+	}
 
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
+	// The route drawn in the puzzle's description but for the top left
+	// corner, where there's more than one shortest route.
+	want := strings.Join([]string{
+		"start: 0,0",
+		"steps: 31",
+		"route:",
+		"\t>>vv<<<<",
+		"\t..vvv<<^",
+		"\t..vv>E^^",
+		"\t..v>>>^^",
+		"\t..>>>>>^",
+		"",
+	}, "\n")
+	if got := e.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
 }
@@ -0,0 +1,32 @@
+package day12
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzReadInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		grid, start, dest, err := readInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// The start and destination are squares on the grid, at the
+		// lowest and highest elevations.
+		if e, ok := grid.At(start); !ok || e != 'a' {
+			t.Errorf("got start %s at elevation %q (on the grid: %t), want it on the grid at a", start, rune(e), ok)
+		}
+		if e, ok := grid.At(dest); !ok || e != 'z' {
+			t.Errorf("got destination %s at elevation %q (on the grid: %t), want it on the grid at z", dest, rune(e), ok)
+		}
+		for _, loc := range grid.Bounds().Locs() {
+			if e, _ := grid.At(loc); e < 'a' || 'z' < e {
+				t.Fatalf("got elevation %d at %s, want a to z", e, loc)
+			}
+		}
+	})
+}
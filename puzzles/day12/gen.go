@@ -0,0 +1,99 @@
+package day12
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math/rand"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+// generate writes a heightmap with size rows, and at least 5, that's four
+// times as wide. Every square has a route to E, so there's a route from S
+// as well as from every other square at elevation a.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	if size < 5 {
+		size = 5
+	}
+	rows, cols := size, size*4
+
+	for attempt := 0; attempt < 100; attempt++ {
+		heights, dest := randomHeights(r, rows, cols)
+
+		lowest := make([]grids.Loc, 0)
+		for y, row := range heights {
+			for x, h := range row {
+				if h == 'a' {
+					lowest = append(lowest, grids.Loc{x, y})
+				}
+			}
+		}
+		if len(lowest) == 0 {
+			// The routes weren't long enough to climb all the way from a
+			// to z.
+			continue
+		}
+
+		start := lowest[r.Intn(len(lowest))]
+		heights[start[1]][start[0]] = 'S'
+		heights[dest[1]][dest[0]] = 'E'
+
+		bw := bufio.NewWriter(w)
+		for _, row := range heights {
+			bw.Write(row)
+			bw.WriteByte('\n')
+		}
+		return bw.Flush()
+	}
+	return errors.New("couldn't come up with a heightmap with any square at elevation a")
+}
+
+// randomHeights walks the grid depth first from the destination, making
+// each square at most one lower than the square it was reached from so
+// that the walk can be followed back up to the destination.
+func randomHeights(r *rand.Rand, rows, cols int) ([][]byte, grids.Loc) {
+	heights := make([][]byte, rows)
+	for y := range heights {
+		heights[y] = make([]byte, cols)
+	}
+	inside := func(loc grids.Loc) bool {
+		return 0 <= loc[0] && loc[0] < cols && 0 <= loc[1] && loc[1] < rows
+	}
+
+	dest := grids.Loc{r.Intn(cols), r.Intn(rows)}
+	heights[dest[1]][dest[0]] = 'z'
+
+	stack := []grids.Loc{dest}
+	for len(stack) > 0 {
+		loc := stack[len(stack)-1]
+
+		unvisited := make([]grids.Loc, 0, 4)
+		for _, next := range loc.Neighbors4() {
+			if inside(next) && heights[next[1]][next[0]] == 0 {
+				unvisited = append(unvisited, next)
+			}
+		}
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[r.Intn(len(unvisited))]
+		h := heights[loc[1]][loc[0]]
+		switch {
+		case r.Intn(4) > 0 && h > 'a':
+			h--
+		case r.Intn(4) == 0:
+			// Going down from a higher square is always possible.
+			h += byte(r.Intn(3))
+			if h > 'z' {
+				h = 'z'
+			}
+		}
+		heights[next[1]][next[0]] = h
+		stack = append(stack, next)
+	}
+
+	return heights, dest
+}
@@ -0,0 +1,41 @@
+package day12
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+func TestGenerate_routes(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		for _, size := range []int{0, 8, 30} {
+			buf := &bytes.Buffer{}
+			if err := generate(rand.New(rand.NewSource(seed)), buf, size); err != nil {
+				t.Fatal(err)
+			}
+			grid, start, dest, err := readInput(buf)
+			if err != nil {
+				t.Fatalf("seed %d, size %d: %v", seed, size, err)
+			}
+
+			// Searching from E backwards, stepping down at most one at a
+			// time, finds every square with a route to E.
+			search := grids.BFS(grid.Bounds(), []grids.Loc{dest}, func(from, to grids.Loc) bool {
+				val, _ := grid.At(to)
+				prevVal, _ := grid.At(from)
+				return prevVal-val <= 1
+			})
+			if !search.Reached(start) {
+				t.Errorf("seed %d, size %d: got no route from S at %s to E at %s", seed, size, start, dest)
+			}
+			for _, loc := range grid.Bounds().Locs() {
+				if !search.Reached(loc) {
+					t.Errorf("seed %d, size %d: got no route from %s to E", seed, size, loc)
+					break
+				}
+			}
+		}
+	}
+}
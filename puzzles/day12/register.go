@@ -0,0 +1,13 @@
+package day12
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(12, 1, registry.OfContext(Puzzle{}.Part1))
+	registry.Register(12, 2, registry.OfContext(Puzzle{}.Part2))
+
+	gen.Register(12, generate)
+}
@@ -1,12 +1,16 @@
 package day13
 
 import (
-	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 type Puzzle struct{}
@@ -31,7 +35,7 @@ func (p Puzzle) Part1(reader io.Reader) (int, error) {
 	return counter, nil
 }
 
-func (p Puzzle) Part2(reader io.Reader) (int, error) {
+func (p Puzzle) Part2(ctx context.Context, reader io.Reader) (int, error) {
 	ss, err := parseAsSlices(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
@@ -65,12 +69,21 @@ outer:
 		}
 	}
 
+	explainDividers(explain.From(ctx), idxs, len(ss))
+
 	return idxs[0] * idxs[1], nil
 }
 
+// explainDividers explains part 2 by where the divider packets ended up
+// among the sorted packets.
+func explainDividers(e *explain.Explanation, idxs []int, packets int) {
+	e.Addf("[[2]]", "packet %d of %d", idxs[0], packets)
+	e.Addf("[[6]]", "packet %d of %d", idxs[1], packets)
+}
+
 func parseAsSlices(reader io.Reader) ([][]any, error) {
 	var out [][]any
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(13, reader)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 		if len(line) == 0 {
@@ -79,11 +92,19 @@ func parseAsSlices(reader io.Reader) ([][]any, error) {
 
 		var l []any
 		if err := json.Unmarshal(line, &l); err != nil {
-			return nil, fmt.Errorf("decoding: %w", err)
+			var serr *json.SyntaxError
+			if errors.As(err, &serr) && 0 < serr.Offset && serr.Offset <= int64(len(line)) {
+				column := int(serr.Offset)
+				return nil, scanner.WrapColumn(column, string(line[column-1:column]), fmt.Errorf("decoding: %w", err))
+			}
+			return nil, scanner.Wrap("", fmt.Errorf("decoding: %w", err))
 		}
 
 		out = append(out, l)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return out, nil
 }
@@ -0,0 +1,37 @@
+package day13
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseAsSlices(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		packets, err := parseAsSlices(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		buf := &bytes.Buffer{}
+		for i, p := range packets {
+			b, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("encoding packet %d, %v: %v", i, p, err)
+			}
+			buf.Write(append(b, '\n'))
+		}
+		again, err := parseAsSlices(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("parsing %q, the packets %v: %v", buf, packets, err)
+		}
+		if !reflect.DeepEqual(again, packets) {
+			t.Errorf("got packets %v after a round trip, want %v", again, packets)
+		}
+	})
+}
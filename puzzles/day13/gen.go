@@ -0,0 +1,35 @@
+package day13
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// generate writes size pairs of packets.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			bw.WriteByte('\n')
+		}
+		bw.WriteString(randomPacket(r, 0) + "\n")
+		bw.WriteString(randomPacket(r, 0) + "\n")
+	}
+	return bw.Flush()
+}
+
+func randomPacket(r *rand.Rand, depth int) string {
+	n := r.Intn(6)
+	values := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		if depth < 4 && r.Intn(4) == 0 {
+			values = append(values, randomPacket(r, depth+1))
+		} else {
+			values = append(values, strconv.Itoa(r.Intn(11)))
+		}
+	}
+	return "[" + strings.Join(values, ",") + "]"
+}
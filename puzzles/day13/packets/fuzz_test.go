@@ -0,0 +1,47 @@
+package packets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParse(f *testing.F) {
+	golden.Seed(f, "../testdata")
+	f.Fuzz(func(t *testing.T, input []byte) {
+		packets, err := Parse(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+		for i, p := range packets {
+			if _, err := parseLine(p.String()); err != nil {
+				t.Fatalf("parsing %q, the string of packet %d: %v", p, i, err)
+			}
+			// Sorting the packets needs one of the two orders to be right.
+			if i > 0 && !packets[i-1].Compare(p) && !p.Compare(packets[i-1]) {
+				t.Errorf("got %q and %q out of order both ways", packets[i-1], p)
+			}
+		}
+	})
+}
+
+func FuzzParseLine(f *testing.F) {
+	for _, line := range []string{"[]", "[1,[2,[3]]]", "[[1],[2,3,4]]", "[[[]]]"} {
+		f.Add(line)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		packet, err := parseLine(line)
+		if err != nil {
+			return
+		}
+		// Anything accepted must survive a round trip.
+		again, err := parseLine(packet.String())
+		if err != nil {
+			t.Fatalf("parsing %q, the string of %q: %v", packet, line, err)
+		}
+		if again.String() != packet.String() {
+			t.Errorf("got %q after a round trip of %q, want %q", again, line, packet)
+		}
+	})
+}
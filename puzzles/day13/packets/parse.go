@@ -1,17 +1,19 @@
 package packets
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 func Parse(reader io.Reader) ([]*Packet, error) {
 	packets := make([]*Packet, 0)
 
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(13, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -19,46 +21,93 @@ func Parse(reader io.Reader) ([]*Packet, error) {
 		}
 
 		packet, err := parseLine(line)
-		if err != nil {
-			return nil, fmt.Errorf("parsing line %q: %w", line, err)
+		var serr *syntaxError
+		if errors.As(err, &serr) {
+			return nil, scanner.WrapColumn(serr.column, serr.text, serr.err)
+		} else if err != nil {
+			return nil, scanner.Wrap("", err)
 		}
 		packets = append(packets, packet)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return packets, nil
 }
 
+// syntaxError is an error at a column of a line.
+type syntaxError struct {
+	column int
+	text   string
+	err    error
+}
+
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("column %d: %q: %v", e.column, e.text, e.err)
+}
+
 func parseLine(line string) (*Packet, error) {
+	runes := []rune(line)
+	if len(runes) == 0 || runes[0] != '[' {
+		return nil, &syntaxError{1, line, errors.New("packet must start with [")}
+	}
+
 	topLevelPacket := newPacket(nil)
 	packet := topLevelPacket
 	sb := &strings.Builder{}
 
-	for _, r := range line[1:] {
+	// The previous rune decides what's allowed to come next, which keeps
+	// things like "[1,,2]" or "[[1]2]" from being silently accepted.
+	prev := '['
+	for i, r := range runes[1:] {
+		column := i + 2
+		if packet == nil {
+			return nil, &syntaxError{column, string(runes[i+1:]), errors.New("trailing characters after the packet")}
+		}
+
 		switch {
 		case r == '[':
+			if prev != '[' && prev != ',' {
+				return nil, &syntaxError{column, string(r), errors.New("unexpected [, want it after [ or ,")}
+			}
 			next := newPacket(packet)
 			packet.addPacket(next)
 			packet = next
 		case r == ']':
+			if prev == ',' {
+				return nil, &syntaxError{column, string(r), errors.New("missing value after ,")}
+			}
 			if sb.Len() > 0 {
 				if err := parseAddInt(packet, sb.String()); err != nil {
-					return nil, fmt.Errorf("parsing value: %w", err)
+					return nil, &syntaxError{column - sb.Len(), sb.String(), err}
 				}
 				sb.Reset()
 			}
 			packet = packet.parent
 		case '0' <= r && r <= '9':
+			if prev == ']' {
+				return nil, &syntaxError{column, string(r), errors.New("unexpected digit after ]")}
+			}
 			sb.WriteRune(r)
 		case r == ',':
+			if prev == '[' || prev == ',' {
+				return nil, &syntaxError{column, string(r), errors.New("missing value before ,")}
+			}
 			if sb.Len() > 0 {
 				if err := parseAddInt(packet, sb.String()); err != nil {
-					return nil, fmt.Errorf("parsing value: %w", err)
+					return nil, &syntaxError{column - sb.Len(), sb.String(), err}
 				}
 				sb.Reset()
 			}
 		default:
-			return nil, fmt.Errorf("illegal character: %q", string(r))
+			return nil, &syntaxError{column, string(r), errors.New("illegal character")}
 		}
+		prev = r
+	}
+
+	if packet != nil {
+		return nil, &syntaxError{len(runes), string(prev), errors.New("missing closing ]")}
 	}
 
 	return topLevelPacket, nil
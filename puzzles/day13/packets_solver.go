@@ -1,11 +1,13 @@
 package day13
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
 
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day13/packets"
 )
 
@@ -44,7 +46,7 @@ func (p PacketSolver) Part1(reader io.Reader) (int, error) {
 	return counter, nil
 }
 
-func (p PacketSolver) Part2(reader io.Reader) (int, error) {
+func (p PacketSolver) Part2(ctx context.Context, reader io.Reader) (int, error) {
 	packs, err := packets.Parse(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
@@ -78,5 +80,7 @@ outer:
 		}
 	}
 
+	explainDividers(explain.From(ctx), idxs, len(packs))
+
 	return idxs[0] * idxs[1], nil
 }
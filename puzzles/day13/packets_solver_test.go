@@ -1,74 +0,0 @@
-package day13_test
-
-import (
-	"os"
-	"strings"
-	"testing"
-
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
-	"github.com/kristofferostlund/adventofcode-2022/puzzles/day13"
-)
-
-func TestPacketSolver(t *testing.T) {
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 13
-			got, err := day13.PacketSolver{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 5196
-			got, err := day13.PacketSolver{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
-
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 140
-			got, err := day13.PacketSolver{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 22134
-			got, err := day13.PacketSolver{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
-}
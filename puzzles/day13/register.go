@@ -0,0 +1,16 @@
+package day13
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(13, 1, registry.Of(Puzzle{}.Part1))
+	registry.Register(13, 2, registry.OfContext(Puzzle{}.Part2))
+
+	registry.RegisterVariant(13, 1, "packets", registry.Of(PacketSolver{}.Part1))
+	registry.RegisterVariant(13, 2, "packets", registry.OfContext(PacketSolver{}.Part2))
+
+	gen.Register(13, generate)
+}
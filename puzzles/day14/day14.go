@@ -61,9 +61,9 @@ func (p Puzzle) solve(grid *Grid, isValid func(loc grids.Loc) bool) (int, error)
 }
 
 func simulateSand(grid *Grid, isValid func(loc grids.Loc) bool) bool {
-	down := grids.Loc{0, 1}
-	left := grids.Loc{-1, 0}
-	right := grids.Loc{1, 0}
+	down := grids.Down.Vector()
+	left := grids.Left.Vector()
+	right := grids.Right.Vector()
 
 	findNext := func(loc grids.Loc) (grids.Loc, bool) {
 		next := loc.Add(down)
@@ -0,0 +1,38 @@
+package day14
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		paths, err := parseInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		sb := &strings.Builder{}
+		for _, path := range paths {
+			points := make([]string, 0, len(path))
+			for _, loc := range path {
+				points = append(points, fmt.Sprintf("%d,%d", loc[0], loc[1]))
+			}
+			sb.WriteString(strings.Join(points, " -> ") + "\n")
+		}
+		again, err := parseInput(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatalf("parsing %q, the paths %v: %v", sb, paths, err)
+		}
+		if !reflect.DeepEqual(again, paths) {
+			t.Errorf("got paths %v after a round trip, want %v", again, paths)
+		}
+	})
+}
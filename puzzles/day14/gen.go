@@ -0,0 +1,99 @@
+package day14
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+// generate writes size paths of rock in a cave that grows with size,
+// below where the sand starts to fall from. Caves where the sand piles
+// all the way up to the source instead of flowing into the abyss are
+// thrown away for new ones, as part 1 never finishes for them.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	for attempt := 0; attempt < 100; attempt++ {
+		paths := randomPaths(r, size)
+
+		grid, err := newGrid(paths, sandStartFrom)
+		if err != nil {
+			return fmt.Errorf("creating grid: %w", err)
+		}
+		if fillsUp(grid) {
+			continue
+		}
+
+		bw := bufio.NewWriter(w)
+		for _, path := range paths {
+			points := make([]string, 0, len(path))
+			for _, loc := range path {
+				points = append(points, fmt.Sprintf("%d,%d", loc[0], loc[1]))
+			}
+			bw.WriteString(strings.Join(points, " -> ") + "\n")
+		}
+		return bw.Flush()
+	}
+	return errors.New("couldn't come up with a cave where the sand flows into the abyss")
+}
+
+func randomPaths(r *rand.Rand, size int) [][]grids.Loc {
+	minX, maxX := sandStartFrom[0]-10-size, sandStartFrom[0]+10+size
+	minY, maxY := 1, 10+size
+	clamp := func(v, min, max int) int {
+		if v < min {
+			return min
+		}
+		if v > max {
+			return max
+		}
+		return v
+	}
+
+	paths := make([][]grids.Loc, 0)
+	for i := 0; i < size; i++ {
+		at := grids.Loc{minX + r.Intn(maxX-minX+1), minY + r.Intn(maxY-minY+1)}
+		path := []grids.Loc{at}
+		for n := 2 + r.Intn(5); len(path) < n; {
+			length := 1 + r.Intn(10)
+			if r.Intn(2) == 0 {
+				length = -length
+			}
+
+			next := at
+			if r.Intn(2) == 0 {
+				next[0] = clamp(at[0]+length, minX, maxX)
+			} else {
+				next[1] = clamp(at[1]+length, minY, maxY)
+			}
+			if next == at {
+				continue
+			}
+
+			path = append(path, next)
+			at = next
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// fillsUp simulates part 1 to see if the sand comes to rest at the source.
+func fillsUp(grid *Grid) bool {
+	filled := false
+	isValid := func(loc grids.Loc) bool {
+		// Sand only ever ends up at the source when it can't move from it.
+		if loc == grid.sandStart {
+			filled = true
+			return false
+		}
+		return grid.InBounds(loc)
+	}
+
+	for simulateSand(grid, isValid) {
+	}
+	return filled
+}
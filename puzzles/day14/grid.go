@@ -1,13 +1,14 @@
 package day14
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
 
 	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 const (
@@ -89,7 +90,7 @@ func (g *Grid) InBounds(at grids.Loc) bool {
 
 func parseInput(reader io.Reader) ([][]grids.Loc, error) {
 	paths := make([][]grids.Loc, 0)
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(14, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -97,28 +98,39 @@ func parseInput(reader io.Reader) ([][]grids.Loc, error) {
 		}
 
 		path := make([]grids.Loc, 0)
-		pointsToParse := strings.Split(line, " -> ")
+		pointsToParse := parse.Split(line, " -> ")
 		for _, pt := range pointsToParse {
-			xs, ys, ok := strings.Cut(pt, ",")
+			xs, ys, ok := strings.Cut(pt.Text, ",")
 			if !ok {
-				return nil, fmt.Errorf("malformed point pair: %q (line: %q)", pt, line)
+				return nil, scanner.WrapField(pt, errors.New("malformed point pair, want x,y"))
 			}
 
 			x, err := strconv.Atoi(xs)
 			if err != nil {
-				return nil, fmt.Errorf("parsing %q: %w", xs, err)
+				return nil, scanner.WrapColumn(pt.Column, xs, fmt.Errorf("parsing x: %w", err))
 			}
 
 			y, err := strconv.Atoi(ys)
 			if err != nil {
-				return nil, fmt.Errorf("parsing %q: %w", ys, err)
+				return nil, scanner.WrapColumn(pt.Column+len(xs)+1, ys, fmt.Errorf("parsing y: %w", err))
 			}
 
-			path = append(path, grids.Loc{x, y})
+			loc := grids.Loc{x, y}
+			if len(path) > 0 {
+				prev := path[len(path)-1]
+				if prev[0] != x && prev[1] != y {
+					return nil, scanner.WrapField(pt, fmt.Errorf("diagonal line from %s, want it to be horizontal or vertical", prev))
+				}
+			}
+
+			path = append(path, loc)
 		}
 
 		paths = append(paths, path)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return paths, nil
 }
@@ -1,32 +1,32 @@
 package day15
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/ints"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/trace"
 )
 
 type Puzzle struct{}
 
-func (p Puzzle) Part1(reader io.Reader, cy int, debug bool) (int, error) {
+func (p Puzzle) Part1(ctx context.Context, reader io.Reader, cy int) (int, error) {
+	tr := trace.From(ctx)
+
 	sensors, err := parseInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
 	}
 
-	var grid *grids.Grid[string]
-	if debug {
-		grid = prepareGrid(sensors)
-		addNoBeaconZones(grid, sensors)
-	}
-
 	xRanges := make([][2]int, 0)
-	for i, s := range sensors {
+	for _, s := range sensors {
 		sx, sy := s.At.XY()
 		manhattan := s.ManhattanDistance()
 
@@ -40,17 +40,10 @@ func (p Puzzle) Part1(reader io.Reader, cy int, debug bool) (int, error) {
 		minX := sx + (straightLine - manhattan)
 		maxX := sx - (straightLine - manhattan)
 
-		if debug {
-			fmt.Println(s.At)
-			fmt.Println("  straight line", straightLine)
-			fmt.Println("  minX", minX)
-			fmt.Println("  maxX", maxX)
-
-			// Print the current sensor's no-beacon zone
-			g := prepareGrid(sensors)
-			addNoBeaconZones(g, sensors[i:i+1])
-			fmt.Printf("  %s\n", g.RenderRow(cy))
-		}
+		tr.Logf("sensor %s: straight line %d, minX %d, maxX %d", s.At, straightLine, minX, maxX)
+		tr.Snapshot(fmt.Sprintf("sensor %s no-beacon zone", s.At), func() string {
+			return renderRow(sensors, [][2]int{{minX, maxX}}, cy)
+		})
 
 		xRanges = append(xRanges, [2]int{minX, maxX})
 	}
@@ -85,21 +78,26 @@ func (p Puzzle) Part1(reader io.Reader, cy int, debug bool) (int, error) {
 		counter += xRange[1] - xRange[0]
 	}
 
-	if debug {
-		fmt.Printf("\n%s\n", grid.RenderRow(cy))
-		fmt.Printf("\n%s\n", grid)
-	}
+	tr.Snapshot("no-beacon zones", func() string {
+		return renderRow(sensors, toUse, cy)
+	})
 
 	return counter, nil
 }
 
-func (p Puzzle) Part2(reader io.Reader, bounds grids.Bounds) (int, error) {
+func (p Puzzle) Part2(ctx context.Context, reader io.Reader, bounds grids.Bounds) (int, error) {
 	sensors, err := parseInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
 	}
 
+	tr := trace.From(ctx)
 	for i, s := range sensors {
+		if err := ctx.Err(); err != nil {
+			return 0, fmt.Errorf("checked %d of %d sensors: %w", i, len(sensors), err)
+		}
+		tr.Count("sensors checked", i)
+
 		// Since there's exactly one point on the map,
 		// it must be exactly 1 space oustide the range.
 		distance := sensors[i].ManhattanDistance() + 1
@@ -112,10 +110,19 @@ func (p Puzzle) Part2(reader io.Reader, bounds grids.Bounds) (int, error) {
 			s.At.Add(grids.Loc{-distance, 0}), // left
 		}
 
+		walked := 0
 		for ei, extreme := range extremes {
 			next := extremes[(ei+1)%len(extremes)]
 
 			for loc := extreme; loc != next; loc = stepTowards(loc, next) {
+				// The real input's sensors are millions of cells around,
+				// so checking once per sensor isn't often enough.
+				if walked++; walked%4096 == 0 {
+					if err := ctx.Err(); err != nil {
+						return 0, fmt.Errorf("checked %d of %d sensors and %d cells around the next: %w", i, len(sensors), walked, err)
+					}
+				}
+
 				if !bounds.IsInside(loc) {
 					continue
 				}
@@ -134,6 +141,7 @@ func (p Puzzle) Part2(reader io.Reader, bounds grids.Bounds) (int, error) {
 
 				if outsideAll {
 					x, y := loc.XY()
+					explainCell(explain.From(ctx), sensors, loc)
 					return x*4000000 + y, nil
 				}
 			}
@@ -143,6 +151,93 @@ func (p Puzzle) Part2(reader io.Reader, bounds grids.Bounds) (int, error) {
 	return 0, errors.New("expected exactly one available space within the area, found none")
 }
 
+const (
+	empty    = "."
+	sensor   = "S"
+	beacon   = "B"
+	noBeacon = "#"
+
+	// maxRenderWidth is the widest row to draw, any wider ones are
+	// described by their ranges as the real input's are millions wide.
+	maxRenderWidth = 200
+)
+
+// renderRow draws row y with the ranges of x without a beacon, or lists
+// the ranges if they're too wide to draw.
+func renderRow(sensors []Sensor, xRanges [][2]int, y int) string {
+	if len(xRanges) == 0 {
+		return ""
+	}
+
+	minX, maxX := xRanges[0][0], xRanges[0][1]
+	for _, r := range xRanges {
+		minX, maxX = ints.Min(minX, r[0]), ints.Max(maxX, r[1])
+	}
+	if maxX-minX >= maxRenderWidth {
+		sb := &strings.Builder{}
+		for i, r := range xRanges {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(sb, "x=%d..%d", r[0], r[1])
+		}
+		return sb.String()
+	}
+
+	row := grids.NewDense(grids.NewBounds(minX, maxX, y, y), empty)
+	for _, r := range xRanges {
+		for x := r[0]; x <= r[1]; x++ {
+			row.Set(grids.Loc{x, y}, noBeacon)
+		}
+	}
+	for _, s := range sensors {
+		for loc, v := range map[grids.Loc]string{s.At: sensor, s.Beacon: beacon} {
+			if row.InBounds(loc) {
+				row.Set(loc, v)
+			}
+		}
+	}
+	return row.Render()
+}
+
+func stepTowards(at, to grids.Loc) grids.Loc {
+	x, y := 0, 0
+	if at[0] > to[0] {
+		x = -1
+	}
+	if at[0] < to[0] {
+		x = 1
+	}
+
+	if at[1] > to[1] {
+		y = -1
+	}
+	if at[1] < to[1] {
+		y = 1
+	}
+
+	return at.Add(grids.Loc{x, y})
+}
+
+// explainCell explains the uncovered cell by the sensors whose ranges
+// it's right on the edge of.
+func explainCell(e *explain.Explanation, sensors []Sensor, loc grids.Loc) {
+	if e == nil {
+		return
+	}
+
+	x, y := loc.XY()
+	e.Addf("cell", "x=%d, y=%d", x, y)
+
+	bordering := &strings.Builder{}
+	for _, s := range sensors {
+		if manhattanDistance(s.At, loc) == s.ManhattanDistance()+1 {
+			fmt.Fprintf(bordering, "sensor at x=%d, y=%d reaching %d\n", s.At[0], s.At[1], s.ManhattanDistance())
+		}
+	}
+	e.Add("just outside", bordering.String())
+}
+
 type Sensor struct {
 	At     grids.Loc
 	Beacon grids.Loc
@@ -168,7 +263,7 @@ func xyDiff(a, b grids.Loc) (int, int) {
 
 func parseInput(reader io.Reader) ([]Sensor, error) {
 	sensors := make([]Sensor, 0)
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(15, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -188,14 +283,18 @@ func parseInput(reader io.Reader) ([]Sensor, error) {
 			&beaconAt[1],
 		)
 		if err != nil {
-			return nil, fmt.Errorf("scanning line %q: %w", line, err)
+			return nil, scanner.Wrap("", fmt.Errorf("scanning line: %w", err))
 		}
 		if got, want := scannedCount, 4; got != want {
-			return nil, fmt.Errorf("malformed line %q, got %d scanned values, want %d", line, got, want)
+			return nil, scanner.Errorf("", "malformed line, got %d scanned values, want %d", got, want)
 		}
 
 		sensors = append(sensors, Sensor{At: sensorAt, Beacon: beaconAt})
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
 	return sensors, nil
 }
 
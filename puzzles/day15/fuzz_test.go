@@ -0,0 +1,34 @@
+package day15
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		sensors, err := parseInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		sb := &strings.Builder{}
+		for _, s := range sensors {
+			fmt.Fprintf(sb, "Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d\n", s.At[0], s.At[1], s.Beacon[0], s.Beacon[1])
+		}
+		again, err := parseInput(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatalf("parsing %q, the sensors %v: %v", sb, sensors, err)
+		}
+		if !reflect.DeepEqual(again, sensors) {
+			t.Errorf("got sensors %v after a round trip, want %v", again, sensors)
+		}
+	})
+}
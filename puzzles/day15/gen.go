@@ -0,0 +1,102 @@
+package day15
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/ints"
+)
+
+// generate writes size sensors, and at least 4, leaving exactly one cell
+// in the real input's area where the distress beacon can be.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	return generateIn(r, w, size, area)
+}
+
+// generateIn is like generate for the given area.
+//
+// Four sensors diagonally outside the area, each reaching all the way to
+// the cell, cover everything else. The rest are spread out around them,
+// where they don't reach the cell. Every sensor's beacon is closer to it
+// than any other beacon, like the puzzle promises.
+func generateIn(r *rand.Rand, w io.Writer, size int, area grids.Bounds) error {
+	if size < 4 {
+		size = 4
+	}
+
+	hidden := grids.Loc{
+		area.MinX() + r.Intn(area.Width()+1),
+		area.MinY() + r.Intn(area.Height()+1),
+	}
+
+	sensors := make([]Sensor, 0, size)
+	offset := ints.Max(area.Width(), area.Height()) + 1
+	for _, dir := range []grids.Loc{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}} {
+		at := hidden.Add(grids.Loc{dir[0] * offset, dir[1] * offset})
+		// Just short of the hidden cell, and away from the area.
+		reach := 2*offset - 1
+		sensors = append(sensors, Sensor{At: at, Beacon: at.Add(grids.Loc{0, dir[1] * reach})})
+	}
+
+	for attempt := 0; len(sensors) < size; attempt++ {
+		if attempt > 1000*size {
+			return fmt.Errorf("couldn't fit %d sensors, only %d", size, len(sensors))
+		}
+
+		at := hidden.Add(grids.Loc{
+			r.Intn(8*offset+1) - 4*offset,
+			r.Intn(8*offset+1) - 4*offset,
+		})
+		// Keeping the sensors about as small as in the real input, as
+		// part 2 walks around the edge of every one.
+		reach := 1 + r.Intn(ints.Min(offset, 1000000))
+		dx := r.Intn(reach + 1)
+		beacon := grids.Loc{dx, reach - dx}
+		if r.Intn(2) == 0 {
+			beacon[0] = -beacon[0]
+		}
+		if r.Intn(2) == 0 {
+			beacon[1] = -beacon[1]
+		}
+
+		s := Sensor{At: at, Beacon: at.Add(beacon)}
+		if fits(s, sensors, hidden) {
+			sensors = append(sensors, s)
+		}
+	}
+
+	r.Shuffle(len(sensors), func(i, j int) {
+		sensors[i], sensors[j] = sensors[j], sensors[i]
+	})
+
+	bw := bufio.NewWriter(w)
+	for _, s := range sensors {
+		fmt.Fprintf(
+			bw,
+			"Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d\n",
+			s.At[0], s.At[1], s.Beacon[0], s.Beacon[1],
+		)
+	}
+	return bw.Flush()
+}
+
+// fits reports whether s can be added to sensors without reaching hidden,
+// and with every sensor's beacon still the one closest to it.
+func fits(s Sensor, sensors []Sensor, hidden grids.Loc) bool {
+	if s.InRangeOf(hidden) {
+		return false
+	}
+
+	for _, other := range sensors {
+		if s.At == other.At || s.At == other.Beacon || s.Beacon == other.At || s.Beacon == other.Beacon {
+			return false
+		}
+		if s.InRangeOf(other.Beacon) || other.InRangeOf(s.Beacon) {
+			return false
+		}
+	}
+	return true
+}
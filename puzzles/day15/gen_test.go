@@ -0,0 +1,51 @@
+package day15
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+)
+
+func TestGenerate_valid(t *testing.T) {
+	small := grids.NewBounds(0, 20, 0, 20)
+
+	for seed := int64(1); seed <= 20; seed++ {
+		for _, size := range []int{0, 8, 30} {
+			buf := &bytes.Buffer{}
+			if err := generateIn(rand.New(rand.NewSource(seed)), buf, size, small); err != nil {
+				t.Fatal(err)
+			}
+			sensors, err := parseInput(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, s := range sensors {
+				for j, other := range sensors {
+					if other.Beacon != s.Beacon && manhattanDistance(s.At, other.Beacon) <= s.ManhattanDistance() {
+						t.Errorf("seed %d, size %d: sensor %d at %s has beacon %d at %s at least as close as its own", seed, size, i, s.At, j, other.Beacon)
+					}
+				}
+			}
+
+			uncovered := make([]grids.Loc, 0, 1)
+			for _, loc := range small.Locs() {
+				covered := false
+				for _, s := range sensors {
+					if s.InRangeOf(loc) {
+						covered = true
+						break
+					}
+				}
+				if !covered {
+					uncovered = append(uncovered, loc)
+				}
+			}
+			if len(uncovered) != 1 {
+				t.Errorf("seed %d, size %d: got uncovered cells %v, want exactly one", seed, size, uncovered)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package day15
+
+import (
+	"context"
+	"io"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+// The row to check and the area to search in the real input.
+var (
+	row  = 2000000
+	area = grids.NewBounds(0, 4000000, 0, 4000000)
+)
+
+func part1(cy int) registry.Solver {
+	return registry.OfContext(func(ctx context.Context, reader io.Reader) (int, error) {
+		return Puzzle{}.Part1(ctx, reader, cy)
+	})
+}
+
+func part2(bounds grids.Bounds) registry.Solver {
+	return registry.OfContext(func(ctx context.Context, reader io.Reader) (int, error) {
+		return Puzzle{}.Part2(ctx, reader, bounds)
+	})
+}
+
+func init() {
+	// The row to check and the area to search differ between the example
+	// and the real input, so the example's values get a variant of their own.
+	registry.Register(15, 1, part1(row))
+	registry.Register(15, 2, part2(area))
+
+	registry.RegisterVariant(15, 1, "example", part1(10))
+	registry.RegisterVariant(15, 2, "example", part2(grids.NewBounds(0, 20, 0, 20)))
+
+	gen.Register(15, generate)
+}
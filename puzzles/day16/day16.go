@@ -1,20 +1,22 @@
 package day16
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/ints"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/maps"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/queues"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/trace"
 )
 
 type Puzzle struct{}
 
-func (p Puzzle) Part1(reader io.Reader) (int, error) {
+func (p Puzzle) Part1(ctx context.Context, reader io.Reader) (int, error) {
 	valves, err := parseInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
@@ -32,17 +34,26 @@ func (p Puzzle) Part1(reader io.Reader) (int, error) {
 		// ~0.3s or so for the real input vs ~20s. Quite the improvement!
 		return fmt.Sprintf("%s-%d-%d", s.Position, s.OpenCount(), s.Time)
 	}
-	endStates := simulateEndStates(valves, maxTime, keyFunc)
+	endStates, err := simulateEndStates(ctx, valves, maxTime, keyFunc)
+	if err != nil {
+		return 0, fmt.Errorf("simulating end states: %w", err)
+	}
 
-	maxPressure := 0
+	var best State
 	for _, state := range endStates {
-		maxPressure = ints.Max(maxPressure, state.Pressure)
+		if state.Pressure > best.Pressure {
+			best = state
+		}
 	}
 
-	return maxPressure, nil
+	if e := explain.From(ctx); e != nil {
+		e.Add("schedule", best.schedule.String(maxTime))
+	}
+
+	return best.Pressure, nil
 }
 
-func (p Puzzle) Part2(reader io.Reader) (int, error) {
+func (p Puzzle) Part2(ctx context.Context, reader io.Reader) (int, error) {
 	valves, err := parseInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
@@ -57,7 +68,12 @@ func (p Puzzle) Part2(reader io.Reader) (int, error) {
 		// searchspace is smaller (less time allowed).
 		return fmt.Sprintf("%s-%b-%d", s.Position, s.BitMask, s.Time)
 	}
-	endStates := simulateEndStates(valves, maxTime, keyFunc)
+	endStates, err := simulateEndStates(ctx, valves, maxTime, keyFunc)
+	if err != nil {
+		return 0, fmt.Errorf("simulating end states: %w", err)
+	}
+
+	defer trace.Phase(trace.From(ctx), "pairing paths")()
 
 	var maxOpenCount float64 = 0
 	for _, v := range valves {
@@ -66,34 +82,57 @@ func (p Puzzle) Part2(reader io.Reader) (int, error) {
 		}
 	}
 	duoPaths := make(map[uint64]int)
+	// The schedules of the paths, only kept track of when explaining.
+	schedules := make(map[uint64]*opening)
 	for _, state := range endStates {
 		percentOpened := float64(state.OpenCount()) / maxOpenCount
 		// We're aiming for a *rough* 50/50 split, 40/60 is a rough 50/5O!
 		if 0.4 <= percentOpened || percentOpened <= 0.6 {
 			if pressure, ok := duoPaths[state.BitMask]; !ok || pressure < state.Pressure {
 				duoPaths[state.BitMask] = state.Pressure
+				schedules[state.BitMask] = state.schedule
 			}
 		}
 	}
 
 	max := 0
+	var bestA, bestB uint64
 	for a, aMax := range duoPaths {
 		for b, bMax := range duoPaths {
 			// We're looking for pairs where there's no overlap at all.
-			if a&b == 0 {
-				max = ints.Max(max, aMax+bMax)
+			if a&b == 0 && aMax+bMax > max {
+				max, bestA, bestB = aMax+bMax, a, b
 			}
 		}
 	}
 
+	if e := explain.From(ctx); e != nil {
+		e.Addf("you", "%d of the pressure", duoPaths[bestA])
+		e.Add("your schedule", schedules[bestA].String(maxTime))
+		e.Addf("the elephant", "%d of the pressure", duoPaths[bestB])
+		e.Add("the elephant's schedule", schedules[bestB].String(maxTime))
+	}
+
 	return max, nil
 }
 
-func simulateEndStates(valves []Valve, maxTime int, keyFunc func(next *State) string) map[string]State {
+func simulateEndStates(ctx context.Context, valves []Valve, maxTime int, keyFunc func(next *State) string) (map[string]State, error) {
+	tr := trace.From(ctx)
+	defer trace.Phase(tr, "simulating end states")()
+
 	endStates := make(map[string]State)
 
-	pq := initPriorityQueue(valves)
-	for pq.Len() > 0 {
+	pq := initPriorityQueue(valves, explain.From(ctx) != nil)
+	for explored := 0; pq.Len() > 0; explored++ {
+		// Checking every state would be a bit wasteful.
+		if explored%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("explored %d states with %d left in the queue: %w", explored, pq.Len(), err)
+			}
+			tr.Count("explored", explored)
+			tr.Count("queue", pq.Len())
+		}
+
 		state := pq.PopT()
 
 		if state.Time == maxTime-1 {
@@ -116,10 +155,10 @@ func simulateEndStates(valves []Valve, maxTime int, keyFunc func(next *State) st
 			pq.PushT(&next, -next.Pressure)
 		}
 	}
-	return endStates
+	return endStates, nil
 }
 
-func initPriorityQueue(valves []Valve) *queues.PriorityQueue[State] {
+func initPriorityQueue(valves []Valve, scheduled bool) *queues.PriorityQueue[State] {
 	pq := queues.NewPriorityQueue[State]()
 	pq.PushT(&State{
 		Time:     0,
@@ -128,6 +167,7 @@ func initPriorityQueue(valves []Valve) *queues.PriorityQueue[State] {
 		// This is copie around and shared across all states.
 		// I'm not entirely sure I like it, but it makes the code a bit simpler.
 		valveLookup: maps.LookupOf(valves, func(v Valve) string { return v.ID }),
+		scheduled:   scheduled,
 	}, 0)
 	return pq
 }
@@ -139,6 +179,41 @@ type State struct {
 	BitMask  uint64
 
 	valveLookup map[string]Valve
+
+	// The valves opened so far are only kept track of when scheduled is
+	// set, which is when the solver is asked to explain itself.
+	scheduled bool
+	schedule  *opening
+}
+
+// opening is a valve opened during a minute, linked to the valve
+// opened before it.
+type opening struct {
+	minute int
+	valve  Valve
+	prev   *opening
+}
+
+// String lists the openings in the order they happened, with how much
+// pressure each valve releases before time runs out.
+func (o *opening) String(maxTime int) string {
+	openings := make([]*opening, 0)
+	for ; o != nil; o = o.prev {
+		openings = append(openings, o)
+	}
+
+	sb := &strings.Builder{}
+	for i := len(openings) - 1; i >= 0; i-- {
+		o := openings[i]
+		fmt.Fprintf(
+			sb, "minute %d: open %s, releasing %d/min, %d in total\n",
+			o.minute, o.valve.ID, o.valve.FlowRate, o.valve.FlowRate*(maxTime-o.minute),
+		)
+	}
+	if sb.Len() == 0 {
+		return "no valves opened"
+	}
+	return sb.String()
 }
 
 func (s *State) OpenValve(v Valve) {
@@ -157,6 +232,8 @@ func (s State) Copy() State {
 		BitMask:  s.BitMask,
 
 		valveLookup: s.valveLookup,
+		scheduled:   s.scheduled,
+		schedule:    s.schedule,
 	}
 }
 
@@ -207,6 +284,9 @@ func getNextStates(state State) []State {
 		// Open the valve
 		next.OpenValve(v)
 		next.Time++
+		if next.scheduled {
+			next.schedule = &opening{minute: next.Time, valve: v, prev: state.schedule}
+		}
 		next.IncreasePressure()
 		nextStates = append(nextStates, next)
 	}
@@ -226,8 +306,9 @@ func getNextStates(state State) []State {
 
 func parseInput(reader io.Reader) ([]Valve, error) {
 	valves := make([]Valve, 0)
+	seen := make(map[string]struct{})
 
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(16, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -236,16 +317,30 @@ func parseInput(reader io.Reader) ([]Valve, error) {
 
 		v, err := parseValve(line)
 		if err != nil {
-			return nil, fmt.Errorf("parsing valve: %w", err)
+			return nil, scanner.Wrap("", fmt.Errorf("parsing valve: %w", err))
+		}
+		if _, ok := seen[v.ID]; ok {
+			// The ID follows "Valve ", which might contain it too.
+			return nil, scanner.WrapColumn(len("Valve ")+1, v.ID, fmt.Errorf("duplicate valve %s", v.ID))
 		}
+		seen[v.ID] = struct{}{}
 
 		valves = append(valves, v)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	sort.Slice(valves, func(i, j int) bool {
 		return valves[i].ID < valves[j].ID
 	})
 
+	// Each valve gets a bit of its own in the state's bit mask, which
+	// starts at the third bit.
+	if maxValves := 64 - 2; len(valves) > maxValves {
+		return nil, fmt.Errorf("got %d valves, can't keep track of more than %d", len(valves), maxValves)
+	}
+
 	var b uint64 = 2
 	for i := 0; i < len(valves); i++ {
 		b = b << 1
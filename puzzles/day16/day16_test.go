@@ -1,87 +1,46 @@
 package day16_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"strings"
 	"testing"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day16"
 )
 
 func TestPuzzle(t *testing.T) {
-	exampleInput := `
-Valve AA has flow rate=0; tunnels lead to valves DD, II, BB
-Valve BB has flow rate=13; tunnels lead to valves CC, AA
-Valve CC has flow rate=2; tunnels lead to valves DD, BB
-Valve DD has flow rate=20; tunnels lead to valves CC, AA, EE
-Valve EE has flow rate=3; tunnels lead to valves FF, DD
-Valve FF has flow rate=0; tunnels lead to valves EE, GG
-Valve GG has flow rate=0; tunnels lead to valves FF, HH
-Valve HH has flow rate=22; tunnel leads to valve GG
-Valve II has flow rate=0; tunnels lead to valves AA, JJ
-Valve JJ has flow rate=21; tunnel leads to valve II
-`
-
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 1651
-			got, err := day16.Puzzle{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 2124
-			got, err := day16.Puzzle{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
+	golden.Run(t, 16)
+}
 
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 1707
-			got, err := day16.Puzzle{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
+func TestPuzzle_duplicate(t *testing.T) {
+	// The ID is in "Valve" too, but it's the ID that's duplicated.
+	line := "Valve al has flow rate=0; tunnel leads to valve al\n"
+	_, err := day16.Puzzle{}.Part1(context.Background(), strings.NewReader(line+line))
+
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, want a parse error", err)
+	}
+	if perr.Line != 2 || perr.Column != 7 || perr.Text != "al" {
+		t.Errorf("got error at line %d, column %d, text %q, want line 2, column 7, text %q", perr.Line, perr.Column, perr.Text, "al")
+	}
+}
 
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
+func TestPuzzle_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
 
-			want := 2775
-			got, err := day16.Puzzle{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
+	f, err := os.Open("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
 
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
+	if _, err := (day16.Puzzle{}).Part2(ctx, f); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
 }
@@ -0,0 +1,36 @@
+package day16
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		valves, err := parseInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+		seen := make(map[uint64]bool, len(valves))
+		for i, v := range valves {
+			if seen[v.B] || v.B&(v.B-1) != 0 || v.B < 4 {
+				t.Fatalf("got bit %b for valve %s, want a bit of its own from the third", v.B, v)
+			}
+			seen[v.B] = true
+			if i > 0 && valves[i-1].ID >= v.ID {
+				t.Fatalf("got valve %s after %s, want them sorted and unique", v, valves[i-1])
+			}
+		}
+		// A few minutes of anything parsed must simulate, even with tunnels
+		// to valves that aren't there.
+		key := func(s *State) string { return fmt.Sprintf("%s-%b-%d", s.Position, s.BitMask, s.Time) }
+		if _, err := simulateEndStates(context.Background(), valves, 5, key); err != nil {
+			t.Errorf("simulating: %v", err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package day16
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/ints"
+)
+
+// generate writes a connected network of size valves, between 2 and the
+// 62 that can be kept track of, starting at AA. About a quarter of them
+// have a flow rate, like in the real input, but never more than 15 to
+// keep part 2 from exploding.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	n := ints.Max(2, ints.Min(size, 62))
+
+	names := []string{"AA"}
+	seen := map[string]bool{"AA": true}
+	for len(names) < n {
+		name := string([]byte{byte('A' + r.Intn(26)), byte('A' + r.Intn(26))})
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	tunnels := make([][]int, n)
+	connected := make(map[[2]int]bool)
+	connect := func(a, b int) {
+		if a == b || connected[[2]int{a, b}] {
+			return
+		}
+		connected[[2]int{a, b}], connected[[2]int{b, a}] = true, true
+		tunnels[a] = append(tunnels[a], b)
+		tunnels[b] = append(tunnels[b], a)
+	}
+	// A random tree keeps every valve reachable, with a few more tunnels
+	// to make some loops.
+	for i := 1; i < n; i++ {
+		connect(i, r.Intn(i))
+	}
+	for i := 0; i < n/3; i++ {
+		connect(r.Intn(n), r.Intn(n))
+	}
+
+	flowRates := make([]int, n)
+	for _, i := range r.Perm(n - 1)[:ints.Min(n-1, 15, 1+n/4)] {
+		// AA, the first valve, never has a flow rate.
+		flowRates[i+1] = 1 + r.Intn(25)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, i := range r.Perm(n) {
+		leadsTo := make([]string, 0, len(tunnels[i]))
+		for _, j := range tunnels[i] {
+			leadsTo = append(leadsTo, names[j])
+		}
+
+		if len(leadsTo) == 1 {
+			fmt.Fprintf(bw, "Valve %s has flow rate=%d; tunnel leads to valve %s\n", names[i], flowRates[i], leadsTo[0])
+		} else {
+			fmt.Fprintf(bw, "Valve %s has flow rate=%d; tunnels lead to valves %s\n", names[i], flowRates[i], strings.Join(leadsTo, ", "))
+		}
+	}
+	return bw.Flush()
+}
@@ -1,12 +1,14 @@
 package day17
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
 
 	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/slices"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/trace"
 )
 
 const (
@@ -55,25 +57,27 @@ var rockBounds = func() []grids.Bounds {
 
 type Puzzle struct{}
 
-func (p Puzzle) Part1(reader io.Reader) (int, error) {
+func (p Puzzle) Part1(ctx context.Context, reader io.Reader) (int, error) {
 	directions, err := parseInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
 	}
 
-	return simulateRocks(directions, 2022), nil
+	return simulateRocks(ctx, directions, 2022)
 }
 
-func (p Puzzle) Part2(reader io.Reader) (int, error) {
+func (p Puzzle) Part2(ctx context.Context, reader io.Reader) (int, error) {
 	directions, err := parseInput(reader)
 	if err != nil {
 		return 0, fmt.Errorf("parsing input: %w", err)
 	}
 
-	return simulateRocks(directions, 1000000000000), nil
+	return simulateRocks(ctx, directions, 1000000000000)
 }
 
-func simulateRocks(directions []string, simulateCount int) int {
+func simulateRocks(ctx context.Context, directions []string, simulateCount int) (int, error) {
+	tr := trace.From(ctx)
+
 	grid := grids.NewGrid(".")
 	for i := 0; i < 7; i++ {
 		grid.Set(grids.Loc{i, 0}, "-")
@@ -127,6 +131,10 @@ func simulateRocks(directions []string, simulateCount int) int {
 		case isHorizontal:
 			// Do nothing if either !next.WithinSides(grid) or next.Collides(grid
 		case !isHorizontal && next.Collides(grid):
+			if err := ctx.Err(); err != nil {
+				return 0, fmt.Errorf("simulated %d of %d rocks: %w", rockCount, simulateCount, err)
+			}
+
 			addToGrid(grid, rock)
 			rockCount++
 
@@ -146,7 +154,11 @@ func simulateRocks(directions []string, simulateCount int) int {
 				mul := remainder / rDelta
 				rockCount += mul * rDelta
 				simulatedHeight = mul * hDelta
+
+				tr.Logf("pattern repeats every %d rocks, skipping ahead to rock %d", rDelta, rockCount)
+				tr.Snapshot("repeating pattern", func() string { return subsetGridOf(grid).String() })
 			}
+			tr.Count("rocks", rockCount)
 
 			rock, ri = nextRock(grid, rockCount)
 		case !isHorizontal:
@@ -154,7 +166,7 @@ func simulateRocks(directions []string, simulateCount int) int {
 		}
 	}
 
-	return grid.Bounds().Height() + simulatedHeight
+	return grid.Bounds().Height() + simulatedHeight, nil
 }
 
 func addToGrid(grid *grids.Grid[string], rock Rock) {
@@ -191,49 +203,25 @@ func nextDirection(directions []string, i int) (grids.Loc, int) {
 	}
 }
 
+// subsetGridOf crops the grid to its top rows, down to the first row by
+// which every column has a rock in it, as falling rocks can't get past
+// it.
 func subsetGridOf(grid *grids.Grid[string]) *grids.Grid[string] {
-	var got, want uint64 = 0, 0
-
 	bounds := grid.Bounds()
-	xb := make([]uint64, bounds.Width()+1)
-	var b uint64 = 1
-	for i := 0; i < len(xb); i++ {
-		b <<= 1
-		xb[i] = b
-		want |= b
-	}
-
-	subGrid := grids.NewGrid(".")
-
+	covered := make(map[int]struct{}, bounds.Width()+1)
 	for y := bounds.MinY(); y <= bounds.MaxY(); y++ {
 		for x := bounds.MinX(); x <= bounds.MaxX(); x++ {
-			at := grids.Loc{x, y}
-			value, ok := grid.At(at)
-			if ok {
-				got |= xb[x]
-				subGrid.Set(at, value)
-
-				if got == want {
-					return subGrid
-				}
+			if _, ok := grid.At(grids.Loc{x, y}); ok {
+				covered[x] = struct{}{}
 			}
 		}
-	}
 
-	// for i := len(fallenRocks) - 1; i >= 0; i-- {
-	// 	rock := fallenRocks[i]
-	// 	for _, l := range rock {
-	// 		subGrid.Set(l, "#")
-	// 		x, _ := l.XY()
-	// 		got |= xb[x]
-	// 	}
-
-	// 	if got == want {
-	// 		return subGrid
-	// 	}
-	// }
+		if len(covered) == bounds.Width()+1 {
+			return grid.Crop(grids.NewBounds(bounds.MinX(), bounds.MaxX(), bounds.MinY(), y))
+		}
+	}
 
-	return subGrid
+	return grid.Copy()
 }
 
 type Rock []grids.Loc
@@ -267,7 +255,7 @@ func (r Rock) Copy() Rock {
 }
 
 func parseInput(reader io.Reader) ([]string, error) {
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(17, reader)
 
 	directions := make([]string, 0)
 	for scanner.Scan() {
@@ -276,16 +264,20 @@ func parseInput(reader io.Reader) ([]string, error) {
 			continue
 		}
 
-		for _, r := range line {
+		for i, r := range []rune(line) {
 			s := string(r)
 			switch s {
 			case left, right:
 			default:
-				return nil, fmt.Errorf("illegal direction %q", s)
+				return nil, scanner.WrapColumn(i+1, s, fmt.Errorf("illegal direction %q", s))
 			}
 
 			directions = append(directions, s)
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
 	return directions, nil
 }
@@ -0,0 +1,30 @@
+package day17
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		directions, err := parseInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		joined := strings.Join(directions, "")
+		again, err := parseInput(strings.NewReader(joined))
+		if err != nil {
+			t.Fatalf("parsing %q, the directions: %v", joined, err)
+		}
+		if !reflect.DeepEqual(again, directions) {
+			t.Errorf("got directions %q after a round trip, want %q", again, directions)
+		}
+	})
+}
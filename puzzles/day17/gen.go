@@ -0,0 +1,28 @@
+package day17
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+)
+
+// generate writes a jet pattern of size pushes, and at least 100. The
+// solvers spot the pattern repeating by looking at the top of the tower
+// down to where every column is filled, which shorter patterns tend to
+// keep some column from ever being.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	if size < 100 {
+		size = 100
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		if r.Intn(2) == 0 {
+			bw.WriteString(left)
+		} else {
+			bw.WriteString(right)
+		}
+	}
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
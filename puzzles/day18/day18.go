@@ -1,11 +1,11 @@
 package day18
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"strconv"
-	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 type Puzzle struct{}
@@ -96,26 +96,30 @@ func (p Point3D) XYZ() (x, y, z int) {
 
 func parseInput(reader io.Reader) ([]Point3D, error) {
 	points := make([]Point3D, 0)
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(18, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
-		ss := strings.Split(line, ",")
+		ss := parse.Split(line, ",")
 		if got, want := len(ss), 3; got != want {
-			return nil, fmt.Errorf("got %d dimensions, want %d", got, want)
+			return nil, scanner.Errorf("", "got %d dimensions, want %d", got, want)
 		}
 
 		point := Point3D{}
 		for i, ds := range ss {
-			val, err := strconv.Atoi(ds)
+			val, err := strconv.Atoi(ds.Text)
 			if err != nil {
-				return nil, fmt.Errorf("parsing %q: %w", ds, err)
+				return nil, scanner.WrapField(ds, fmt.Errorf("parsing dimension %d: %w", i+1, err))
 			}
 			point[i] = val
 		}
 		points = append(points, point)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
 	return points, nil
 }
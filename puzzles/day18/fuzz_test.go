@@ -0,0 +1,34 @@
+package day18
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		points, err := parseInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		sb := &strings.Builder{}
+		for _, p := range points {
+			fmt.Fprintf(sb, "%d,%d,%d\n", p[0], p[1], p[2])
+		}
+		again, err := parseInput(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatalf("parsing %q, the points %v: %v", sb, points, err)
+		}
+		if !reflect.DeepEqual(again, points) {
+			t.Errorf("got points %v after a round trip, want %v", again, points)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package day18
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generate writes size distinct cubes, packed into a space that's just
+// large enough to leave some of them apart.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	side := 2
+	for side*side*side < 2*size {
+		side++
+	}
+
+	seen := make(map[Point3D]bool)
+	bw := bufio.NewWriter(w)
+	for len(seen) < size {
+		cube := Point3D{r.Intn(side), r.Intn(side), r.Intn(side)}
+		if seen[cube] {
+			continue
+		}
+		seen[cube] = true
+		fmt.Fprintf(bw, "%d,%d,%d\n", cube[0], cube[1], cube[2])
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,13 @@
+package day18
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(18, 1, registry.Of(Puzzle{}.Part1))
+	// Part 2 isn't solved yet.
+
+	gen.Register(18, generate)
+}
@@ -1,10 +1,11 @@
 package day2
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 type Puzzle struct {
@@ -99,7 +100,7 @@ func (p Puzzle) Part2(reader io.Reader) (int, error) {
 func (Puzzle) readStrategy(reader io.Reader) ([][2]string, error) {
 	pairs := make([][2]string, 0)
 
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(2, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -108,10 +109,13 @@ func (Puzzle) readStrategy(reader io.Reader) ([][2]string, error) {
 
 		a, b, ok := strings.Cut(line, " ")
 		if !ok {
-			return nil, fmt.Errorf("malformed line %q", line)
+			return nil, scanner.Errorf("", "malformed line, want two moves separated by a space")
 		}
 		pairs = append(pairs, [2]string{a, b})
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return pairs, nil
 }
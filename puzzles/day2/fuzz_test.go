@@ -0,0 +1,36 @@
+package day2
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzReadStrategy(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Add([]byte(" \r\r"))
+	f.Fuzz(func(t *testing.T, input []byte) {
+		pairs, err := NewPuzzle().readStrategy(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip. The lines end in
+		// \r\n, as the scanner drops a \r before the \n that a move may
+		// end with.
+		sb := &strings.Builder{}
+		for _, pair := range pairs {
+			sb.WriteString(pair[0] + " " + pair[1] + "\r\n")
+		}
+		again, err := NewPuzzle().readStrategy(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatalf("reading %q, the pairs %q: %v", sb, pairs, err)
+		}
+		if !reflect.DeepEqual(again, pairs) {
+			t.Errorf("got pairs %q after a round trip, want %q", again, pairs)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package day2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generate writes a strategy guide of size rounds.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(bw, "%c %c\n", 'A'+r.Intn(3), 'X'+r.Intn(3))
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,13 @@
+package day2
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(2, 1, registry.Of(NewPuzzle().Part1))
+	registry.Register(2, 2, registry.Of(NewPuzzle().Part2))
+
+	gen.Register(2, generate)
+}
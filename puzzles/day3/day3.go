@@ -1,10 +1,10 @@
 package day3
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/sets"
 )
 
@@ -98,31 +98,60 @@ func (p Puzzle) groupsOf(lines []string) [][3]sets.Set[rune] {
 func (Puzzle) readPairs(reader io.Reader) ([][2]string, error) {
 	pairs := make([][2]string, 0)
 
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(3, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
+		if err := validateItems(scanner); err != nil {
+			return nil, err
+		}
+		if len(line)%2 != 0 {
+			return nil, scanner.Errorf("", "got %d items, want an even number to split into two compartments", len(line))
+		}
 
 		a, b := line[:len(line)/2], line[len(line)/2:]
 		pairs = append(pairs, [2]string{a, b})
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return pairs, nil
 }
 
 func (Puzzle) readLines(reader io.Reader) ([]string, error) {
 	lines := make([]string, 0)
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(3, reader)
 	for scanner.Scan() {
-		if line := scanner.Text(); line != "" {
-			lines = append(lines, line)
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if err := validateItems(scanner); err != nil {
+			return nil, err
 		}
+		lines = append(lines, line)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
 	return lines, nil
 }
 
+// validateItems checks that every item on the scanner's current line is
+// a letter, as only letters have a priority.
+func validateItems(scanner *parse.Scanner) error {
+	for i, r := range []rune(scanner.Text()) {
+		if !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') {
+			return scanner.WrapColumn(i+1, string(r), fmt.Errorf("illegal item %q, want a letter", r))
+		}
+	}
+	return nil
+}
+
 func (Puzzle) runeSetOf(s string) sets.Set[rune] {
 	return sets.Of([]rune(s))
 }
@@ -1,82 +1,35 @@
 package day3_test
 
 import (
-	"os"
+	"errors"
+	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day3"
 )
 
 func TestPuzzle(t *testing.T) {
-	exampleInput := `vJrwpWtwJgWrhcsFMMfFFhFp
-jqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL
-PmmdzqPrVvPwwTWBwg
-wMqvLMZHhHMvwLHjbvcjnnSBnvTQFn
-ttgJtRGJQctTZtZT
-CrZsJsPPZsGzwwsLwLmpwMDw
-`
-
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 157
-			got, err := day3.Puzzle{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 8394
-			got, err := day3.Puzzle{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
-
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 70
-			got, err := day3.Puzzle{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 2413
-			got, err := day3.Puzzle{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
+	golden.Run(t, 3)
+}
 
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
+func TestPuzzle_readError(t *testing.T) {
+	errRead := errors.New("read failed")
+
+	for part, solve := range map[int]func(io.Reader) (int, error){1: day3.Puzzle{}.Part1, 2: day3.Puzzle{}.Part2} {
+		lines := "vJrwpWtwJgWrhcsFMMfFFhFp\njqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL\nPmmdzqPrVvPwwTWBwg\n"
+		input := io.MultiReader(strings.NewReader(lines), iotest.ErrReader(errRead))
+		_, err := solve(input)
+
+		var perr *parse.Error
+		if !errors.As(err, &perr) || perr.Line != 4 {
+			t.Errorf("got error %v from part %d, want a parse error at line 4", err, part)
+		}
+		if !errors.Is(err, errRead) {
+			t.Errorf("got error %v from part %d, want it to keep the read error", err, part)
+		}
+	}
 }
@@ -0,0 +1,54 @@
+package day3
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzReadPairs(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		pairs, err := Puzzle{}.readPairs(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// The compartments are the halves of the lines part 2 reads.
+		lines, err := Puzzle{}.readLines(bytes.NewReader(input))
+		if err != nil {
+			t.Fatalf("got error %v reading the lines of pairs %q", err, pairs)
+		}
+		if len(lines) != len(pairs) {
+			t.Fatalf("got %d lines and %d pairs, want as many", len(lines), len(pairs))
+		}
+		for i, pair := range pairs {
+			if len(pair[0]) != len(pair[1]) || pair[0]+pair[1] != lines[i] {
+				t.Errorf("got pair %q of line %q, want its two halves", pair, lines[i])
+			}
+		}
+	})
+}
+
+func FuzzReadLines(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		lines, err := Puzzle{}.readLines(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		joined := strings.Join(lines, "\n")
+		again, err := Puzzle{}.readLines(strings.NewReader(joined))
+		if err != nil {
+			t.Fatalf("reading %q, the lines %q: %v", joined, lines, err)
+		}
+		if !reflect.DeepEqual(again, lines) {
+			t.Errorf("got lines %q after a round trip, want %q", again, lines)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package day3
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+)
+
+const items = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// generate writes size groups of three rucksacks. Every group is written
+// in a row and shares exactly one badge, and the compartments of every
+// rucksack share exactly one item.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		// The badge goes first, and the rest is split between the elves so
+		// their rucksacks share nothing else.
+		shuffled := []byte(items)
+		r.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		badge, pool := shuffled[0], shuffled[1:]
+
+		perElf := len(pool) / 3
+		for elf := 0; elf < 3; elf++ {
+			own := pool[elf*perElf : (elf+1)*perElf]
+			bw.Write(rucksack(r, badge, own))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// rucksack fills the compartments with the elf's own items, where the
+// first one is in both compartments and the badge is in the first.
+func rucksack(r *rand.Rand, badge byte, own []byte) []byte {
+	shared, first, second := own[0], own[1:len(own)/2], own[len(own)/2:]
+
+	n := 2 + r.Intn(15)
+	a := []byte{shared, badge}
+	for len(a) < n {
+		a = append(a, first[r.Intn(len(first))])
+	}
+	b := []byte{shared}
+	for len(b) < n {
+		b = append(b, second[r.Intn(len(second))])
+	}
+
+	for _, c := range [][]byte{a, b} {
+		r.Shuffle(len(c), func(i, j int) {
+			c[i], c[j] = c[j], c[i]
+		})
+	}
+	return append(a, b...)
+}
@@ -0,0 +1,13 @@
+package day3
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(3, 1, registry.Of(Puzzle{}.Part1))
+	registry.Register(3, 2, registry.Of(Puzzle{}.Part2))
+
+	gen.Register(3, generate)
+}
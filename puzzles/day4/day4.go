@@ -1,12 +1,12 @@
 package day4
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
 
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/sets"
 )
 
@@ -48,29 +48,32 @@ func (p Puzzle) Part2(reader io.Reader) (int, error) {
 
 func (p Puzzle) readPairs(reader io.Reader) ([][2][2]int, error) {
 	pairs := make([][2][2]int, 0)
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(4, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
 
-		rangeStrA, rangeStrB, ok := strings.Cut(line, ",")
-		if !ok {
-			return nil, fmt.Errorf("malformed line %q", line)
+		ranges := parse.Split(line, ",")
+		if len(ranges) != 2 {
+			return nil, scanner.Errorf("", "malformed line, want two ranges separated by a comma")
 		}
 
-		rangeA, err := p.parseRange(rangeStrA)
+		rangeA, err := p.parseRange(ranges[0].Text)
 		if err != nil {
-			return nil, fmt.Errorf("parsing range A: %w", err)
+			return nil, scanner.WrapField(ranges[0], fmt.Errorf("parsing range A: %w", err))
 		}
-		rangeB, err := p.parseRange(rangeStrB)
+		rangeB, err := p.parseRange(ranges[1].Text)
 		if err != nil {
-			return nil, fmt.Errorf("parsing range B: %w", err)
+			return nil, scanner.WrapField(ranges[1], fmt.Errorf("parsing range B: %w", err))
 		}
 
 		pairs = append(pairs, [2][2]int{rangeA, rangeB})
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return pairs, nil
 }
@@ -1,82 +1,27 @@
 package day4_test
 
 import (
-	"os"
+	"errors"
 	"strings"
 	"testing"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day4"
 )
 
 func TestPuzzle(t *testing.T) {
-	exampleInput := `2-4,6-8
-2-3,4-5
-5-7,7-9
-2-8,3-7
-6-6,4-6
-2-6,4-8
-`
-
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 2
-			got, err := day4.Puzzle{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := 651
-			got, err := day4.Puzzle{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
-
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := 4
-			got, err := day4.Puzzle{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
+	golden.Run(t, 4)
+}
 
-			want := 956
-			got, err := day4.Puzzle{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
+func TestPuzzle_malformed(t *testing.T) {
+	_, err := day4.Puzzle{}.Part1(strings.NewReader("2-4,6-8\n1-2,3-x\n"))
 
-			if got != want {
-				t.Errorf("got %d, want %d", got, want)
-			}
-		})
-	})
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, want a parse error", err)
+	}
+	if perr.Line != 2 || perr.Column != 5 || perr.Text != "3-x" {
+		t.Errorf("got error at line %d, column %d, text %q, want line 2, column 5, text %q", perr.Line, perr.Column, perr.Text, "3-x")
+	}
 }
@@ -0,0 +1,34 @@
+package day4
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzReadPairs(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		pairs, err := Puzzle{}.readPairs(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		sb := &strings.Builder{}
+		for _, p := range pairs {
+			fmt.Fprintf(sb, "%d-%d,%d-%d\n", p[0][0], p[0][1], p[1][0], p[1][1])
+		}
+		again, err := Puzzle{}.readPairs(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatalf("reading %q, the pairs %v: %v", sb, pairs, err)
+		}
+		if !reflect.DeepEqual(again, pairs) {
+			t.Errorf("got pairs %v after a round trip, want %v", again, pairs)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package day4
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generate writes size pairs of section assignments.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		a, b := sections(r), sections(r)
+		fmt.Fprintf(bw, "%d-%d,%d-%d\n", a[0], a[1], b[0], b[1])
+	}
+	return bw.Flush()
+}
+
+func sections(r *rand.Rand) [2]int {
+	from := 1 + r.Intn(99)
+	return [2]int{from, from + r.Intn(100-from)}
+}
@@ -1,13 +1,15 @@
 package day5
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	stcks "github.com/kristofferostlund/adventofcode-2022/pkg/stacks"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 type Puzzle struct{}
@@ -18,19 +20,7 @@ func (p Puzzle) Part1(reader io.Reader) (string, error) {
 		return "", fmt.Errorf("parsing input: %w", err)
 	}
 
-	for _, inst := range instructions {
-		count, from, to := inst[0], inst[1], inst[2]
-
-		values := stacks[from].PopN(count)
-		stacks[to].Push(values...)
-	}
-
-	sb := &strings.Builder{}
-	for i := 1; i <= len(stacks); i++ {
-		sb.WriteString(stacks[i].Pop())
-	}
-
-	return sb.String(), nil
+	return p.rearrange(stacks, instructions, false)
 }
 
 func (p Puzzle) Part2(reader io.Reader) (string, error) {
@@ -39,20 +29,33 @@ func (p Puzzle) Part2(reader io.Reader) (string, error) {
 		return "", fmt.Errorf("parsing input: %w", err)
 	}
 
-	for _, inst := range instructions {
+	return p.rearrange(stacks, instructions, true)
+}
+
+// rearrange carries out the instructions, moving the crates one at a time
+// or all at once, and returns the crates on top of the stacks. A stack
+// left empty has no crate to add.
+func (Puzzle) rearrange(stacks map[int]*stcks.Stack[string], instructions [][3]int, atOnce bool) (string, error) {
+	for i, inst := range instructions {
 		count, from, to := inst[0], inst[1], inst[2]
+		if count > stacks[from].Len() {
+			return "", fmt.Errorf("instruction %d moves %d crates from stack %d, which only has %d", i+1, count, from, stacks[from].Len())
+		}
 
 		values := stacks[from].PopN(count)
-		for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
-			values[i], values[j] = values[j], values[i]
+		if atOnce {
+			for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+				values[i], values[j] = values[j], values[i]
+			}
 		}
-
 		stacks[to].Push(values...)
 	}
 
 	sb := &strings.Builder{}
 	for i := 1; i <= len(stacks); i++ {
-		sb.WriteString(stacks[i].Pop())
+		if stacks[i].Len() > 0 {
+			sb.WriteString(stacks[i].Pop())
+		}
 	}
 
 	return sb.String(), nil
@@ -63,7 +66,7 @@ func (p Puzzle) parseInput(reader io.Reader) (map[int]*stcks.Stack[string], [][3
 	instructions := make([][3]int, 0)
 
 	state := "parsing_stacks"
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(5, reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		// For example input to work in tests...
@@ -77,13 +80,19 @@ func (p Puzzle) parseInput(reader io.Reader) (map[int]*stcks.Stack[string], [][3
 				state = "parsing_instructions"
 				continue
 			}
-			if line[:2] == " 1" {
-				// Reading the line with the numbers.
-				// Ignoring this as the convention is easy enough.
+			if strings.HasPrefix(line, " 1") {
+				// The line with the numbers has every stack, including
+				// those without any crates.
+				if err := p.parseNumbers(scanner, stacks); err != nil {
+					return nil, nil, err
+				}
 				continue
 			}
 
-			letters := p.getIndexedLetters(line)
+			letters, err := p.parseCrates(scanner)
+			if err != nil {
+				return nil, nil, err
+			}
 			for idx, letter := range letters {
 				if stacks[idx] == nil {
 					stacks[idx] = &stcks.Stack[string]{}
@@ -95,49 +104,93 @@ func (p Puzzle) parseInput(reader io.Reader) (map[int]*stcks.Stack[string], [][3
 				continue
 			}
 
-			count, from, to, err := p.parseInstruction(line)
+			count, from, to, err := p.parseInstruction(scanner)
 			if err != nil {
-				return nil, nil, fmt.Errorf("parsing instructions")
+				return nil, nil, err
+			}
+			if stacks[from] == nil || stacks[to] == nil {
+				return nil, nil, scanner.Errorf("", "moving from stack %d to %d, but there are only %d stacks", from, to, len(stacks))
 			}
 
 			instructions = append(instructions, [3]int{count, from, to})
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	for i := 1; i <= len(stacks); i++ {
+		if stacks[i] == nil {
+			return nil, nil, fmt.Errorf("got %d stacks but no stack %d", len(stacks), i)
+		}
+	}
 
 	return stacks, instructions, nil
 }
 
-func (Puzzle) getIndexedLetters(line string) map[int]string {
-	idx := 1
+// parseCrates returns the crates on the scanner's current line by stack,
+// each drawn like [A] in the first three of its stack's four columns.
+func (Puzzle) parseCrates(scanner *parse.Scanner) (map[int]string, error) {
+	line := scanner.Text()
 	letters := make(map[int]string, 0)
-	for i := 0; i < len(line); i++ {
-		if (i-1)%4 == 0 {
-			if line[i] != ' ' {
-				letters[idx] = string(line[i])
-			}
-			idx++
+	for start, idx := 0, 1; start < len(line); start, idx = start+4, idx+1 {
+		end := start + 3
+		if end > len(line) {
+			end = len(line)
+		}
+
+		crate := line[start:end]
+		if strings.TrimSpace(crate) == "" {
+			continue
+		}
+		if len(crate) != 3 || crate[0] != '[' || crate[1] == ' ' || crate[2] != ']' {
+			column := utf8.RuneCountInString(line[:start]) + 1
+			return nil, scanner.WrapColumn(column, crate, fmt.Errorf("malformed crate in stack %d, want one like [A]", idx))
+		}
+		letters[idx] = crate[1:2]
+	}
+	return letters, nil
+}
+
+// parseNumbers checks that the stacks on the scanner's current line are
+// numbered from 1 and up, and adds the stacks that have no crates.
+func (Puzzle) parseNumbers(scanner *parse.Scanner, stacks map[int]*stcks.Stack[string]) error {
+	want := 1
+	for _, f := range parse.Split(scanner.Text(), " ") {
+		if f.Text == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(f.Text); err != nil || n != want {
+			return scanner.WrapField(f, fmt.Errorf("got stack number %q, want %d", f.Text, want))
 		}
+		if stacks[want] == nil {
+			stacks[want] = &stcks.Stack[string]{}
+		}
+		want++
 	}
-	return letters
+	return nil
 }
 
-func (Puzzle) parseInstruction(line string) (int, int, int, error) {
-	ss := strings.Split(line, " ")
+func (Puzzle) parseInstruction(scanner *parse.Scanner) (int, int, int, error) {
+	ss := parse.Split(scanner.Text(), " ")
 	if len(ss) != 6 {
-		return 0, 0, 0, fmt.Errorf("malformed instruction line %q", line)
+		return 0, 0, 0, scanner.Errorf("", "malformed instruction, want \"move N from A to B\"")
 	}
 
-	count, err := strconv.Atoi(ss[1])
+	count, err := strconv.Atoi(ss[1].Text)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("parsing count: %w", err)
+		return 0, 0, 0, scanner.WrapField(ss[1], fmt.Errorf("parsing count: %w", err))
+	}
+	if count < 0 {
+		return 0, 0, 0, scanner.WrapField(ss[1], fmt.Errorf("got count %d, can't move fewer than 0 crates", count))
 	}
-	from, err := strconv.Atoi(ss[3])
+	from, err := strconv.Atoi(ss[3].Text)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("parsing from: %w", err)
+		return 0, 0, 0, scanner.WrapField(ss[3], fmt.Errorf("parsing from: %w", err))
 	}
-	to, err := strconv.Atoi(ss[5])
+	to, err := strconv.Atoi(ss[5].Text)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("parsing to: %w", err)
+		return 0, 0, 0, scanner.WrapField(ss[5], fmt.Errorf("parsing to: %w", err))
 	}
 
 	return count, from, to, nil
@@ -1,86 +1,67 @@
 package day5_test
 
 import (
-	"os"
+	"errors"
+	"strconv"
 	"strings"
 	"testing"
 
-	"github.com/kristofferostlund/adventofcode-2022/pkg/relative"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/puzzles/day5"
 )
 
 func TestPuzzle(t *testing.T) {
-	exampleInput := `
-    [D]
-[N] [C]
-[Z] [M] [P]
- 1   2   3
-
-move 1 from 2 to 1
-move 3 from 1 to 3
-move 2 from 2 to 1
-move 1 from 1 to 2
-`
-
-	t.Run("Part1", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := "CMZ"
-			got, err := day5.Puzzle{}.Part1(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %q, want %q", got, want)
-			}
-		})
-
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
-
-			want := "FZCMJCRHZ"
-			got, err := day5.Puzzle{}.Part1(f)
-			if err != nil {
-				t.Fatalf("solving part 1: %v", err)
-			}
+	golden.Run(t, 5)
+}
 
-			if got != want {
-				t.Errorf("got %s, want %s", got, want)
-			}
-		})
-	})
+func TestPuzzle_malformed(t *testing.T) {
+	input := strings.Join([]string{
+		"    [D]    ",
+		"[N] [C]    ",
+		"[Z] [M] [P]",
+		" 1   2   3 ",
+		"",
+		"move 1 from 2 to 1",
+		"move x from 1 to 3",
+	}, "\n")
 
-	t.Run("Part2", func(t *testing.T) {
-		t.Run("example input", func(t *testing.T) {
-			want := "MCD"
-			got, err := day5.Puzzle{}.Part2(strings.NewReader(exampleInput))
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
-			}
-			if got != want {
-				t.Errorf("got %q, want %q", got, want)
-			}
-		})
+	_, err := day5.Puzzle{}.Part1(strings.NewReader(input))
 
-		t.Run("input.txt", func(t *testing.T) {
-			f, err := os.Open(relative.Filepath("./input.txt"))
-			if err != nil {
-				t.Fatalf("opening file: %v", err)
-			}
-			defer f.Close()
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, want a parse error", err)
+	}
+	if perr.Line != 7 || perr.Column != 6 || perr.Text != "x" {
+		t.Errorf("got error at line %d, column %d, text %q, want line 7, column 6, text %q", perr.Line, perr.Column, perr.Text, "x")
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("got error %v, want it to keep the underlying error", err)
+	}
+}
 
-			want := "JSDHQMZGF"
-			got, err := day5.Puzzle{}.Part2(f)
-			if err != nil {
-				t.Fatalf("solving part 2: %v", err)
+func TestPuzzle_impossible(t *testing.T) {
+	drawing := "    [D]    \n[N] [C]    \n[Z] [M] [P]\n 1   2   3 \n\n"
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no drawing", "move 9 from 2 to 1\n"},
+		{"too many crates", drawing + "move 4 from 1 to 2\n"},
+		{"emptied stack", drawing + "move 2 from 1 to 2\nmove 1 from 1 to 3\n"},
+		{"negative count", drawing + "move -1 from 1 to 2\n"},
+		{"missing stack", drawing + "move 1 from 4 to 1\n"},
+		{"gap in the stacks", "[A]     [C]\n\nmove 1 from 1 to 3\n"},
+		{"misnumbered stacks", "[A] [B]\n 1   3 \n\nmove 1 from 1 to 3\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := (day5.Puzzle{}).Part1(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("got %q from part 1, want an error", got)
 			}
-
-			if got != want {
-				t.Errorf("got %s, want %s", got, want)
+			if got, err := (day5.Puzzle{}).Part2(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("got %q from part 2, want an error", got)
 			}
 		})
-	})
+	}
 }
@@ -0,0 +1,29 @@
+package day5
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseInput(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Add([]byte("move 9 from 2 to 1\n"))
+	f.Add([]byte("[A]     [C]\n\nmove 1 from 1 to 3\n"))
+	f.Fuzz(func(t *testing.T, input []byte) {
+		if _, _, err := (Puzzle{}).parseInput(bytes.NewReader(input)); err != nil {
+			return
+		}
+		// Both cranes move as many crates between the same stacks, so
+		// they leave as many stacks with a crate on top.
+		one, err1 := Puzzle{}.Part1(bytes.NewReader(input))
+		all, err2 := Puzzle{}.Part2(bytes.NewReader(input))
+		if (err1 == nil) != (err2 == nil) {
+			t.Fatalf("got errors %v and %v, want both or neither", err1, err2)
+		}
+		if len(one) != len(all) {
+			t.Errorf("got %q from part 1 and %q from part 2, want as many crates", one, all)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package day5
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// generate writes a drawing of 2 to 9 stacks of crates followed by size
+// moves. The moves never empty a stack, so there's always a crate on top
+// of each one to read the answer from.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	stacks := make([][]byte, 2+r.Intn(8))
+	for i := range stacks {
+		height := 1 + r.Intn(8)
+		if i == 0 && height < 2 {
+			// With more crates than stacks there's always a stack to move
+			// crates from.
+			height = 2
+		}
+		for j := 0; j < height; j++ {
+			stacks[i] = append(stacks[i], byte('A'+r.Intn(26)))
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	writeDrawing(bw, stacks)
+	fmt.Fprintln(bw)
+
+	for i := 0; i < size; i++ {
+		from := r.Intn(len(stacks))
+		for len(stacks[from]) < 2 {
+			from = r.Intn(len(stacks))
+		}
+		to := r.Intn(len(stacks) - 1)
+		if to >= from {
+			to++
+		}
+		count := 1 + r.Intn(len(stacks[from])-1)
+
+		moved := stacks[from][len(stacks[from])-count:]
+		stacks[to] = append(stacks[to], moved...)
+		stacks[from] = stacks[from][:len(stacks[from])-count]
+
+		fmt.Fprintf(bw, "move %d from %d to %d\n", count, from+1, to+1)
+	}
+	return bw.Flush()
+}
+
+func writeDrawing(w io.Writer, stacks [][]byte) {
+	height := 0
+	for _, s := range stacks {
+		if len(s) > height {
+			height = len(s)
+		}
+	}
+
+	for level := height - 1; level >= 0; level-- {
+		crates := make([]string, 0, len(stacks))
+		for _, s := range stacks {
+			if level < len(s) {
+				crates = append(crates, fmt.Sprintf("[%c]", s[level]))
+			} else {
+				crates = append(crates, "   ")
+			}
+		}
+		fmt.Fprintln(w, strings.Join(crates, " "))
+	}
+
+	numbers := make([]string, 0, len(stacks))
+	for i := range stacks {
+		numbers = append(numbers, fmt.Sprintf(" %d ", i+1))
+	}
+	fmt.Fprintln(w, strings.Join(numbers, " "))
+}
@@ -0,0 +1,19 @@
+package day6
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzFindMarker(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		want, wantErr := Puzzle{}.findMarker(bytes.NewReader(input), 4)
+		got, err := StreamSolver{}.findMarker(bytes.NewReader(input), 4)
+		if got != want || (err == nil) != (wantErr == nil) {
+			t.Errorf("got %d, %v from the stream, want %d, %v", got, err, want, wantErr)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package day6
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+)
+
+// generate writes a datastream with size characters before its first
+// start-of-message marker, followed by as many again after it. There's
+// at least one character before it, as a marker right at the start isn't
+// looked for.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	if size < 1 {
+		size = 1
+	}
+
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+
+	// Too few letters to ever make a start-of-message marker, but maybe
+	// enough for a start-of-packet marker.
+	few := letters[:3+r.Intn(11)]
+	marker := []byte(letters)
+	r.Shuffle(len(marker), func(i, j int) {
+		marker[i], marker[j] = marker[j], marker[i]
+	})
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		bw.WriteByte(few[r.Intn(len(few))])
+	}
+	bw.Write(marker[:14])
+	for i := 0; i < size; i++ {
+		bw.WriteByte(letters[r.Intn(len(letters))])
+	}
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
@@ -0,0 +1,16 @@
+package day6
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(6, 1, registry.Of(Puzzle{}.Part1))
+	registry.Register(6, 2, registry.Of(Puzzle{}.Part2))
+
+	registry.RegisterVariant(6, 1, "stream", registry.Of(StreamSolver{}.Part1))
+	registry.RegisterVariant(6, 2, "stream", registry.Of(StreamSolver{}.Part2))
+
+	gen.Register(6, generate)
+}
@@ -0,0 +1,59 @@
+package day6
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamSolver finds the markers in a single pass over the datastream,
+// only ever keeping the last few characters in memory.
+type StreamSolver struct{}
+
+func (s StreamSolver) Part1(reader io.Reader) (int, error) {
+	return s.findMarker(reader, 4)
+}
+
+func (s StreamSolver) Part2(reader io.Reader) (int, error) {
+	return s.findMarker(reader, 14)
+}
+
+// findMarker finds the same markers as Puzzle.findMarker, which only looks
+// at the characters before the one being read, and never at the start.
+func (StreamSolver) findMarker(reader io.Reader, seqLen int) (int, error) {
+	br := bufio.NewReader(reader)
+
+	window := make([]byte, seqLen)
+	counts := [256]int{}
+	distinct := 0
+
+	for i := 0; ; i++ {
+		c, err := br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("reading input: %w", err)
+		}
+
+		if i > seqLen && distinct == seqLen {
+			return i, nil
+		}
+
+		if i >= seqLen {
+			old := window[i%seqLen]
+			counts[old]--
+			if counts[old] == 0 {
+				distinct--
+			}
+		}
+		window[i%seqLen] = c
+		if counts[c] == 0 {
+			distinct++
+		}
+		counts[c]++
+	}
+
+	return 0, fmt.Errorf("no marker found")
+}
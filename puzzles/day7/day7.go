@@ -1,17 +1,20 @@
 package day7
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/explain"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 )
 
 type Puzzle struct{}
 
 func (p Puzzle) Part1(reader io.Reader) (int, error) {
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(7, reader)
 
 	tree, err := p.buildTree(scanner)
 	if err != nil {
@@ -29,8 +32,8 @@ func (p Puzzle) Part1(reader io.Reader) (int, error) {
 	return totalSizeUnder100000, nil
 }
 
-func (p Puzzle) Part2(reader io.Reader) (int, error) {
-	scanner := bufio.NewScanner(reader)
+func (p Puzzle) Part2(ctx context.Context, reader io.Reader) (int, error) {
+	scanner := parse.NewScanner(7, reader)
 
 	tree, err := p.buildTree(scanner)
 	if err != nil {
@@ -57,10 +60,17 @@ func (p Puzzle) Part2(reader io.Reader) (int, error) {
 		}
 	})
 
+	if e := explain.From(ctx); e != nil {
+		free := maxSize - totalSize
+		e.Addf("disk", "%d used of %d, %d free of the %d needed", totalSize, maxSize, free, requiredSize)
+		e.Addf("deleting", "%s", smallestDir.Path())
+		e.Addf("frees", "%d, leaving %d free", smallestDir.Size(), free+smallestDir.Size())
+	}
+
 	return smallestDir.Size(), nil
 }
 
-func (Puzzle) buildTree(scanner *bufio.Scanner) (*Dir, error) {
+func (Puzzle) buildTree(scanner *parse.Scanner) (*Dir, error) {
 	tree := newDir("", nil)
 	tree.AddDir("/")
 
@@ -74,28 +84,33 @@ func (Puzzle) buildTree(scanner *bufio.Scanner) (*Dir, error) {
 		}
 
 		if strings.HasPrefix(line, "$") {
-			args := strings.Split(line, " ")[1:]
-			command = args[0]
+			args := parse.Split(line, " ")[1:]
+			if len(args) == 0 {
+				return nil, scanner.Errorf("", "missing command")
+			}
+			command = args[0].Text
 
 			switch command {
 			case "cd":
-				dirname := args[1]
-				nextDir, err := dir.Cd(dirname)
+				if len(args) != 2 {
+					return nil, scanner.Errorf("", "got %d arguments to cd, want 1", len(args)-1)
+				}
+				nextDir, err := dir.Cd(args[1].Text)
 				if err != nil {
-					return nil, fmt.Errorf("finding next dir: %w", err)
+					return nil, scanner.WrapField(args[1], fmt.Errorf("finding next dir: %w", err))
 				}
 				dir = nextDir
 				continue
 			case "ls":
 				// No arguments to ls
 			default:
-				return nil, fmt.Errorf("illegal command: %q", command)
+				return nil, scanner.WrapField(args[0], fmt.Errorf("illegal command: %q", command))
 			}
 		} else {
 			if command == "ls" {
 				a, b, ok := strings.Cut(line, " ")
 				if !ok {
-					return nil, fmt.Errorf("malformed output for command %q: %q", command, line)
+					return nil, scanner.Errorf("", "malformed output for command %q", command)
 				}
 
 				if a == "dir" {
@@ -103,14 +118,18 @@ func (Puzzle) buildTree(scanner *bufio.Scanner) (*Dir, error) {
 				} else {
 					size, err := strconv.Atoi(a)
 					if err != nil {
-						return nil, fmt.Errorf("parsing file size of %s: %w", b, err)
+						return nil, scanner.WrapColumn(1, a, fmt.Errorf("parsing file size of %s: %w", b, err))
 					}
 					dir.Files[b] = size
 				}
 			} else {
-				return nil, fmt.Errorf("illegal output for command %q: %q", command, line)
+				return nil, scanner.Errorf("", "illegal output for command %q", command)
 			}
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
 	return tree, nil
 }
@@ -0,0 +1,16 @@
+package day7
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+)
+
+func FuzzBuildTree(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		_, _ = Puzzle{}.buildTree(parse.NewScanner(7, bytes.NewReader(input)))
+	})
+}
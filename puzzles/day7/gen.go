@@ -0,0 +1,106 @@
+package day7
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generate writes the terminal output of exploring a random directory
+// tree with size files. The sizes add up to roughly between 40000000 and
+// 70000000, so they fit on the disk but something has to be deleted to
+// make room for the update.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	root := &genDir{names: make(map[string]bool)}
+	dirs := []*genDir{root}
+
+	weights := make([]int, 0)
+	files := make([]*genFile, 0)
+	for len(files) < size {
+		parent := dirs[r.Intn(len(dirs))]
+		if r.Intn(3) == 0 {
+			dir := &genDir{name: parent.uniqueName(r, ""), names: make(map[string]bool)}
+			parent.dirs = append(parent.dirs, dir)
+			dirs = append(dirs, dir)
+			continue
+		}
+
+		extension := ""
+		if r.Intn(2) == 0 {
+			extension = "." + randomName(r, 3)
+		}
+		file := &genFile{name: parent.uniqueName(r, extension)}
+		parent.files = append(parent.files, file)
+		files = append(files, file)
+		weights = append(weights, 1+r.Intn(100000))
+	}
+
+	total := 0
+	for _, weight := range weights {
+		total += weight
+	}
+	budget := 40000000 + r.Intn(30000000)
+	for i, file := range files {
+		file.size = share(weights[i], total, budget)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "$ cd /")
+	root.write(bw)
+	return bw.Flush()
+}
+
+// share returns the share of budget that w is out of total, and at least 1.
+func share(w, total, budget int) int {
+	if size := int(int64(w) * int64(budget) / int64(total)); size > 0 {
+		return size
+	}
+	return 1
+}
+
+type genDir struct {
+	name  string
+	dirs  []*genDir
+	files []*genFile
+	names map[string]bool
+}
+
+type genFile struct {
+	name string
+	size int
+}
+
+func (d *genDir) uniqueName(r *rand.Rand, extension string) string {
+	for {
+		name := randomName(r, 8) + extension
+		if !d.names[name] {
+			d.names[name] = true
+			return name
+		}
+	}
+}
+
+func (d *genDir) write(w io.Writer) {
+	fmt.Fprintln(w, "$ ls")
+	for _, dir := range d.dirs {
+		fmt.Fprintf(w, "dir %s\n", dir.name)
+	}
+	for _, file := range d.files {
+		fmt.Fprintf(w, "%d %s\n", file.size, file.name)
+	}
+
+	for _, dir := range d.dirs {
+		fmt.Fprintf(w, "$ cd %s\n", dir.name)
+		dir.write(w)
+		fmt.Fprintln(w, "$ cd ..")
+	}
+}
+
+func randomName(r *rand.Rand, maxLen int) string {
+	name := make([]byte, 1+r.Intn(maxLen))
+	for i := range name {
+		name[i] = byte('a' + r.Intn(26))
+	}
+	return string(name)
+}
@@ -0,0 +1,13 @@
+package day7
+
+import (
+	"github.com/kristofferostlund/adventofcode-2022/pkg/gen"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/registry"
+)
+
+func init() {
+	registry.Register(7, 1, registry.Of(Puzzle{}.Part1))
+	registry.Register(7, 2, registry.OfContext(Puzzle{}.Part2))
+
+	gen.Register(7, generate)
+}
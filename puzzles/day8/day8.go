@@ -1,10 +1,10 @@
 package day8
 
 import (
-	"bufio"
 	"fmt"
 	"io"
-	"strconv"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
 )
 
 type Puzzle struct{}
@@ -16,42 +16,11 @@ func (p Puzzle) Part1(reader io.Reader) (int, error) {
 	}
 
 	counter := 0
-
-	for x := 0; x < len(grid); x++ {
-		row := grid[x]
-
-		for y := 0; y < len(row); y++ {
-			val := row[y]
-			sides := 4
-
-			for i := y - 1; i >= 0; i-- {
-				if row[i] >= val {
-					sides--
-					break
-				}
-			}
-			for i := y + 1; i < len(row); i++ {
-				if row[i] >= val {
-					sides--
-					break
-				}
-			}
-
-			for i := x - 1; i >= 0; i-- {
-				if grid[i][y] >= val {
-					sides--
-					break
-				}
-			}
-			for i := x + 1; i < len(grid); i++ {
-				if grid[i][y] >= val {
-					sides--
-					break
-				}
-			}
-
-			if sides > 0 {
+	for _, loc := range grid.Bounds().Locs() {
+		for _, d := range grids.Directions {
+			if _, blocked := look(grid, loc, d); !blocked {
 				counter++
+				break
 			}
 		}
 	}
@@ -66,76 +35,49 @@ func (p Puzzle) Part2(reader io.Reader) (int, error) {
 	}
 
 	max := -1
+	for _, loc := range grid.Bounds().Locs() {
+		viewingDistances := 1
+		for _, d := range grids.Directions {
+			trees, _ := look(grid, loc, d)
+			viewingDistances *= trees
+		}
 
-	for x := 0; x < len(grid); x++ {
-		row := grid[x]
-
-		for y := 0; y < len(row); y++ {
-			val := row[y]
-
-			d1 := 0
-			for i := y - 1; i >= 0; i-- {
-				d1++
-				if row[i] >= val {
-					break
-				}
-			}
-
-			d2 := 0
-			for i := y + 1; i < len(row); i++ {
-				d2++
-				if row[i] >= val {
-					break
-				}
-			}
-
-			d3 := 0
-			for i := x - 1; i >= 0; i-- {
-				d3++
-				if grid[i][y] >= val {
-					break
-				}
-			}
-
-			d4 := 0
-			for i := x + 1; i < len(grid); i++ {
-				d4++
-				if grid[i][y] >= val {
-					break
-				}
-			}
-
-			viewingDistances := d1 * d2 * d3 * d4
-			if viewingDistances > max {
-				max = viewingDistances
-			}
+		if viewingDistances > max {
+			max = viewingDistances
 		}
 	}
 
 	return max, nil
 }
 
-func (Puzzle) parseGrid(reader io.Reader) ([][]int, error) {
-	grid := make([][]int, 0)
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
+// look walks from the tree at loc in direction d until the edge of the grid or
+// a tree at least as tall, returning the number of trees seen on the way
+// and whether it was blocked by one.
+func look(grid grids.Cells[int], loc grids.Loc, d grids.Direction) (int, bool) {
+	height, _ := grid.At(loc)
+	step := d.Vector()
+
+	trees := 0
+	for next := loc.Add(step); ; next = next.Add(step) {
+		h, ok := grid.At(next)
+		if !ok {
+			return trees, false
 		}
-
-		row := make([]int, 0, len(line))
-		for _, r := range line {
-			v, err := strconv.Atoi(string(r))
-			if err != nil {
-				return nil, fmt.Errorf("parsing %s: %w", string(r), err)
-			}
-
-			row = append(row, v)
+		trees++
+		if h >= height {
+			return trees, true
 		}
-
-		grid = append(grid, row)
 	}
+}
 
-	return grid, nil
+func (Puzzle) parseGrid(reader io.Reader) (*grids.Dense[int], error) {
+	grid, _, err := grids.Parse(8, reader, treeHeight)
+	return grid, err
+}
+
+func treeHeight(r rune) (int, bool) {
+	if r < '0' || '9' < r {
+		return 0, false
+	}
+	return int(r - '0'), true
 }
@@ -0,0 +1,29 @@
+package day8
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+)
+
+func FuzzParseGrid(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		grid, err := Puzzle{}.parseGrid(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// Anything accepted must survive a round trip.
+		rendered := grid.Render()
+		again, err := Puzzle{}.parseGrid(strings.NewReader(rendered))
+		if err != nil {
+			t.Fatalf("parsing %q, the rendered grid: %v", rendered, err)
+		}
+		if got := again.Render(); got != rendered {
+			t.Errorf("got grid\n%s\nafter a round trip, want\n%s", got, rendered)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package day8
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+)
+
+// generate writes a square grid of tree heights with sides of size.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	if size < 1 {
+		size = 1
+	}
+
+	bw := bufio.NewWriter(w)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			bw.WriteByte(byte('0' + r.Intn(10)))
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
@@ -1,46 +1,44 @@
 package day9
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"strconv"
-	"strings"
 
+	"github.com/kristofferostlund/adventofcode-2022/pkg/grids"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
 	"github.com/kristofferostlund/adventofcode-2022/pkg/sets"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/trace"
+	"github.com/kristofferostlund/adventofcode-2022/puzzles/day9/debug"
 )
 
 type Puzzle struct{}
 
-var directions = map[string][2]int{
-	"R": {0, 1},
-	"L": {0, -1},
-	"U": {1, 0},
-	"D": {-1, 0},
-}
-
-func (p Puzzle) Part1(reader io.Reader) (int, error) {
+func (p Puzzle) Part1(ctx context.Context, reader io.Reader) (int, error) {
 	knots := make([][2]int, 2)
 	visits, err := tailVisits(reader, knots)
 	if err != nil {
 		return 0, fmt.Errorf("getting tail visits: %w", err)
 	}
+	trace.From(ctx).Snapshot("tail visits", func() string { return debug.Render(visits) })
 
 	return len(visits), nil
 }
 
-func (p Puzzle) Part2(reader io.Reader) (int, error) {
+func (p Puzzle) Part2(ctx context.Context, reader io.Reader) (int, error) {
 	knots := make([][2]int, 10)
 	visits, err := tailVisits(reader, knots)
 	if err != nil {
 		return 0, fmt.Errorf("getting tail visits: %w", err)
 	}
+	trace.From(ctx).Snapshot("tail visits", func() string { return debug.Render(visits) })
 
 	return len(visits), nil
 }
 
 func tailVisits(reader io.Reader, knots [][2]int) ([][2]int, error) {
-	scanner := bufio.NewScanner(reader)
+	scanner := parse.NewScanner(9, reader)
 	tVisits := sets.Of([][2]int{knots[len(knots)-1]})
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -48,13 +46,14 @@ func tailVisits(reader io.Reader, knots [][2]int) ([][2]int, error) {
 			continue
 		}
 
-		v, mut, err := handleInstruction(line)
+		v, d, err := handleInstruction(scanner)
 		if err != nil {
-			return nil, fmt.Errorf("handling instruction: %w", err)
+			return nil, err
 		}
 
-		knots[0][0] += mut[0] * v
-		knots[0][1] += mut[1] * v
+		step := d.Vector()
+		knots[0][0] += step[0] * v
+		knots[0][1] += step[1] * v
 
 		for i := 0; i < v; i++ {
 			for ki := 1; ki < len(knots); ki++ {
@@ -74,25 +73,30 @@ func tailVisits(reader io.Reader, knots [][2]int) ([][2]int, error) {
 			}
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
 	return tVisits.Values(), nil
 }
 
-func handleInstruction(line string) (int, [2]int, error) {
-	d, i, ok := strings.Cut(line, " ")
-	if !ok {
-		return 0, [2]int{}, fmt.Errorf("malformed line: %q", line)
+func handleInstruction(scanner *parse.Scanner) (int, grids.Direction, error) {
+	fields := parse.Split(scanner.Text(), " ")
+	if len(fields) != 2 {
+		return 0, 0, scanner.Errorf("", "malformed instruction, want a direction and a step count")
 	}
+	d, i := fields[0], fields[1]
 
-	v, err := strconv.Atoi(i)
+	v, err := strconv.Atoi(i.Text)
 	if err != nil {
-		return 0, [2]int{}, fmt.Errorf("parsing %q: %w", i, err)
+		return 0, 0, scanner.WrapField(i, fmt.Errorf("parsing step count: %w", err))
 	}
 
-	mut, ok := directions[d]
-	if !ok {
-		return 0, [2]int{}, fmt.Errorf("illegal direction: %q", d)
+	dir, err := grids.ParseDirection(d.Text)
+	if err != nil {
+		return 0, 0, scanner.WrapField(d, err)
 	}
-	return v, mut, nil
+	return v, dir, nil
 }
 
 func absInt(v int) int {
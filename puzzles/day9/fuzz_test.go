@@ -0,0 +1,40 @@
+package day9
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kristofferostlund/adventofcode-2022/pkg/golden"
+	"github.com/kristofferostlund/adventofcode-2022/pkg/parse"
+)
+
+func FuzzHandleInstruction(f *testing.F) {
+	golden.Seed(f, golden.Dir)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		// Only parsing, as simulating could take forever with large step counts.
+		scanner := parse.NewScanner(9, bytes.NewReader(input))
+		for scanner.Scan() {
+			if scanner.Text() == "" {
+				continue
+			}
+			steps, dir, err := handleInstruction(scanner)
+			if err != nil {
+				return
+			}
+
+			// Anything accepted must survive a round trip.
+			line := fmt.Sprintf("%c %d", "URDL"[dir], steps)
+			again := parse.NewScanner(9, strings.NewReader(line))
+			again.Scan()
+			gotSteps, gotDir, err := handleInstruction(again)
+			if err != nil {
+				t.Fatalf("parsing %q, the instruction %q: %v", line, scanner.Text(), err)
+			}
+			if gotSteps != steps || gotDir != dir {
+				t.Errorf("got %d steps %s after a round trip of %q, want %d steps %s", gotSteps, gotDir, scanner.Text(), steps, dir)
+			}
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package day9
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generate writes size motions of the head.
+func generate(r *rand.Rand, w io.Writer, size int) error {
+	names := []string{"R", "L", "U", "D"}
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(bw, "%s %d\n", names[r.Intn(len(names))], 1+r.Intn(20))
+	}
+	return bw.Flush()
+}